@@ -0,0 +1,222 @@
+package main
+
+import (
+	"embed"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// defaultAchievements holds the achievement definitions. Adding a badge is a
+// matter of adding an entry to the JSON file, as long as its condition uses
+// one of the kinds AchievementCondition understands.
+//
+//go:embed achievements/achievements.json
+var defaultAchievements embed.FS
+
+// Achievement is a badge the player can unlock.
+type Achievement struct {
+	ID          string               `json:"id"`
+	Name        string               `json:"name"`
+	Description string               `json:"description"`
+	When        AchievementCondition `json:"when"`
+}
+
+// AchievementCondition says when an achievement unlocks. Exactly one of
+// Event, Stat, Stage or Age is set:
+//   - Event: the history has Count events with that action, or events on
+//     StreakDays consecutive days
+//   - Stat: the stat is at least AtLeast
+//   - Stage: the pet has reached that life stage
+//   - Age: the pet is at least that old
+type AchievementCondition struct {
+	Event      string    `json:"event,omitempty"`
+	Count      int       `json:"count,omitempty"`
+	StreakDays int       `json:"streak_days,omitempty"`
+	Stat       string    `json:"stat,omitempty"`
+	AtLeast    int       `json:"at_least,omitempty"`
+	Stage      LifeStage `json:"stage,omitempty"`
+	Age        Duration  `json:"age,omitempty"`
+}
+
+// loadAchievements parses the embedded achievement definitions.
+func loadAchievements() []Achievement {
+	return mustParse(defaultAchievements, "achievements/achievements.json", parseAchievements)
+}
+
+// parseAchievements decodes and validates a list of achievements.
+func parseAchievements(data []byte) ([]Achievement, error) {
+	var list []Achievement
+	if err := json.Unmarshal(data, &list); err != nil {
+		return nil, err
+	}
+	var errs []error
+	seen := make(map[string]bool)
+	for _, a := range list {
+		if a.ID == "" || a.Name == "" {
+			errs = append(errs, fmt.Errorf("achievement %q: id and name are required", a.ID))
+			continue
+		}
+		if seen[a.ID] {
+			errs = append(errs, fmt.Errorf("achievement %q: duplicate id", a.ID))
+		}
+		seen[a.ID] = true
+		if err := a.When.validate(); err != nil {
+			errs = append(errs, fmt.Errorf("achievement %q: %w", a.ID, err))
+		}
+	}
+	return list, errors.Join(errs...)
+}
+
+func (c AchievementCondition) validate() error {
+	kinds := 0
+	for _, set := range []bool{c.Event != "", c.Stat != "", c.Stage != "", c.Age.Duration > 0} {
+		if set {
+			kinds++
+		}
+	}
+	if kinds != 1 {
+		return errors.New("exactly one of event, stat, stage or age must be set")
+	}
+	switch {
+	case c.Event != "" && c.Count <= 0 && c.StreakDays <= 0:
+		return errors.New("event needs count or streak_days")
+	case c.Stat != "" && !contains(statOrder, c.Stat):
+		return fmt.Errorf("unknown stat %q", c.Stat)
+	case c.Stage != "" && stageIndex(c.Stage) < 0:
+		return fmt.Errorf("unknown stage %q", c.Stage)
+	}
+	return nil
+}
+
+// met reports whether b satisfies the condition.
+func (c AchievementCondition) met(b *BitBuddy) bool {
+	switch {
+	case c.Event != "" && c.StreakDays > 0:
+		return b.longestStreak(c.Event) >= c.StreakDays
+	case c.Event != "":
+		return b.countEvents(c.Event) >= c.Count
+	case c.Stat != "":
+		return b.snapshot()[c.Stat] >= c.AtLeast
+	case c.Stage != "":
+		return stageIndex(b.Stage) >= stageIndex(c.Stage)
+	case c.Age.Duration > 0:
+		return b.Age() >= c.Age.Duration
+	}
+	return false
+}
+
+// countEvents counts history events with the given action.
+func (b *BitBuddy) countEvents(action string) int {
+	n := 0
+	for _, e := range b.History {
+		if e.Action == action {
+			n++
+		}
+	}
+	return n
+}
+
+// longestStreak is the longest run of consecutive calendar days with at
+// least one event of the given action.
+func (b *BitBuddy) longestStreak(action string) int {
+	best, run := 0, 0
+	var last time.Time
+	for _, e := range b.History {
+		if e.Action != action {
+			continue
+		}
+		day := dayOf(e.At)
+		switch {
+		case run > 0 && day.Equal(last):
+			continue
+		case run > 0 && day.Equal(last.AddDate(0, 0, 1)):
+			run++
+		default:
+			run = 1
+		}
+		last = day
+		if run > best {
+			best = run
+		}
+	}
+	return best
+}
+
+// CheckAchievements unlocks every achievement whose condition is now met and
+// returns the newly unlocked ones. Dead pets don't earn badges.
+func (b *BitBuddy) CheckAchievements() []Achievement {
+	if b.IsDead() {
+		return nil
+	}
+	var unlocked []Achievement
+	for _, a := range b.sim.Achievements {
+		if _, done := b.Achievements[a.ID]; done || !a.When.met(b) {
+			continue
+		}
+		if b.Achievements == nil {
+			b.Achievements = make(map[string]time.Time)
+		}
+		b.Achievements[a.ID] = b.now()
+		unlocked = append(unlocked, a)
+	}
+	return unlocked
+}
+
+func contains(list []string, s string) bool {
+	for _, v := range list {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
+
+// -- ACHIEVEMENTS SCREEN --
+
+// announceAchievements checks for new badges and adds a toast for each to
+// the status message. It reports whether anything was unlocked.
+func (m *model) announceAchievements() bool {
+	unlocked := m.buddy.CheckAchievements()
+	if len(unlocked) == 0 {
+		return false
+	}
+	lines := make([]string, 0, len(unlocked)+1)
+	if m.statusMessage != "" {
+		lines = append(lines, m.statusMessage)
+	}
+	for _, a := range unlocked {
+		lines = append(lines, "Achievement unlocked: "+a.Name+"!")
+	}
+	m.statusMessage = strings.Join(lines, "\n")
+	return true
+}
+
+// updateAchievements handles keys on the Achievements screen.
+func (m model) updateAchievements(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "esc", "a", "q", "enter":
+		m.showAchievements = false
+	}
+	return m, nil
+}
+
+// renderAchievements lists every badge, unlocked ones with the date earned.
+func (m model) renderAchievements() string {
+	var b strings.Builder
+	b.WriteString(fmt.Sprintf("Achievements (%d/%d)\n\n", len(m.buddy.Achievements), len(m.sim.Achievements)))
+	for _, a := range m.sim.Achievements {
+		if at, ok := m.buddy.Achievements[a.ID]; ok {
+			b.WriteString(selectedChoiceStyle.Render("[*] "+a.Name) + "  " + at.Format("2006-01-02") + "\n")
+		} else {
+			b.WriteString(menuChoiceStyle.Render("[ ] "+a.Name) + "\n")
+		}
+		b.WriteString(menuChoiceStyle.Render("    "+a.Description) + "\n")
+	}
+	b.WriteString("\n" + quitStyle.Render("Esc back"))
+	return b.String()
+}
@@ -0,0 +1,154 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// AttentionCall is the pet asking for something. A call is answered when
+// its reason goes away; if that takes longer than attention.grace it costs
+// a care mistake.
+type AttentionCall struct {
+	Reason string // "hunger", "happiness", "energy", "hygiene" or "sick"
+	Since  time.Time
+	Missed bool `json:",omitempty"` // the grace period ran out and a mistake was counted
+}
+
+// callReasons are the things a pet calls for, in priority order, with the
+// stat bar each one belongs to ("" for none).
+var callReasons = []struct {
+	Reason string
+	Stat   string
+	Ask    string
+}{
+	{"sick", "Health", "feels sick"},
+	{"hunger", "Hunger", "is hungry"},
+	{"energy", "Energy", "is exhausted"},
+	{"happiness", "Happiness", "is lonely"},
+	{"hygiene", "Hygiene", "needs cleaning up"},
+}
+
+// needs reports whether the pet currently wants attention for reason.
+func (b *BitBuddy) needs(reason string) bool {
+	rules := b.rules().Attention
+	switch reason {
+	case "sick":
+		return b.IsSick()
+	case "hunger":
+		return b.Hunger >= rules.HungerAbove
+	case "energy":
+		return b.Energy <= rules.EnergyBelow
+	case "happiness":
+		return b.Happiness <= rules.HappinessBelow
+	case "hygiene":
+		return b.Hygiene <= rules.HygieneBelow
+	}
+	return false
+}
+
+// checkCalls runs once per tick while the pet is awake: it starts a call
+// for every new need, drops calls whose need was met, and counts a care
+// mistake for each call left unanswered past the grace period.
+func (b *BitBuddy) checkCalls() {
+	grace := b.rules().Attention.Grace.Duration
+	open := b.Calls[:0]
+	for _, c := range b.Calls {
+		if !b.needs(c.Reason) {
+			continue
+		}
+		if !c.Missed && b.now().Sub(c.Since) >= grace {
+			b.missCall(&c, b.now())
+		}
+		open = append(open, c)
+	}
+	b.Calls = open
+	for _, r := range callReasons {
+		if b.needs(r.Reason) && b.callFor(r.Reason) == nil {
+			b.Calls = append(b.Calls, AttentionCall{Reason: r.Reason, Since: b.now()})
+			b.record(EventStat, "call", fmt.Sprintf("%s %s", b.Name, r.Ask))
+		}
+	}
+}
+
+// missCall counts a care mistake for the call c, which ran out at at.
+func (b *BitBuddy) missCall(c *AttentionCall, at time.Time) {
+	c.Missed = true
+	b.Mistakes++
+	b.recordAt(at, EventStat, "care-mistake", "Call ignored: "+c.Reason)
+}
+
+// expireCalls counts a care mistake for every open call whose grace period
+// ran out by until, at the time it ran out. CatchUp replays only the start
+// of a long absence tick by tick; this settles the calls left open for the
+// rest of it.
+func (b *BitBuddy) expireCalls(until time.Time) {
+	grace := b.rules().Attention.Grace.Duration
+	for i := range b.Calls {
+		c := &b.Calls[i]
+		if due := c.Since.Add(grace); !c.Missed && !due.After(until) {
+			b.missCall(c, due)
+		}
+	}
+}
+
+// callFor returns the open call for reason, or nil.
+func (b *BitBuddy) callFor(reason string) *AttentionCall {
+	for i := range b.Calls {
+		if b.Calls[i].Reason == reason {
+			return &b.Calls[i]
+		}
+	}
+	return nil
+}
+
+// CareMistakes is how many attention calls went unanswered for too long.
+func (b *BitBuddy) CareMistakes() int {
+	return b.Mistakes
+}
+
+// callingFor reports whether there is an open call for the stat bar.
+func (b *BitBuddy) callingFor(stat string) bool {
+	for _, r := range callReasons {
+		if r.Stat == stat && b.callFor(r.Reason) != nil {
+			return true
+		}
+	}
+	return false
+}
+
+// -- ATTENTION IN THE TUI --
+
+// attentionIcon is drawn beside the pet while it is calling.
+const attentionIcon = "(!)"
+
+// blinkOn alternates roughly twice a second, for blinking icons.
+func (m model) blinkOn() bool {
+	return (m.frame/4)%2 == 0
+}
+
+// callLine describes the most urgent open call, with the time left before
+// it becomes a care mistake.
+func (m model) callLine() string {
+	for _, r := range callReasons {
+		c := m.buddy.callFor(r.Reason)
+		if c == nil {
+			continue
+		}
+		if c.Missed {
+			return fmt.Sprintf("%s %s %s!", attentionIcon, m.buddy.Name, r.Ask)
+		}
+		left := m.sim.Rules.Attention.Grace.Duration - m.sim.Now().Sub(c.Since)
+		return fmt.Sprintf("%s %s %s! (%s)", attentionIcon, m.buddy.Name, r.Ask, formatDuration(max(left, 0)))
+	}
+	return ""
+}
+
+// ringBell sounds the terminal bell. It goes to stderr so it can't upset
+// Bubble Tea's rendering of stdout.
+func ringBell() tea.Msg {
+	fmt.Fprint(os.Stderr, "\a")
+	return nil
+}
@@ -1,6 +1,10 @@
 package main
 
-import "time"
+import (
+	"fmt"
+	"strings"
+	"time"
+)
 
 const (
 	maxStat = 100
@@ -9,72 +13,219 @@ const (
 
 // BitBuddy represents the state of our digital pet.
 type BitBuddy struct {
+    SchemaVersion int // Save format version, see migrations
+
     Name      string
     PetType   string
     Hunger    int // Goes up over time, decreases when fed
     Happiness int // Goes down over time, increases when played with
-    Energy    int // Goes down when playing, increases when sleeping
+    Energy    int // Goes down while awake and playing, regenerates while asleep
+    Health    int // Drops with neglect and illness, restored by Medicine
+    Hygiene   int // Drained by uncleaned messes, restored by Clean
+    Weight    int // Goes up with food (treats especially), down with play
     CreatedAt time.Time
     UpdatedAt time.Time
+
+    Stage       LifeStage // Egg through Elder, driven by age and care quality
+    Form        string    // Adult form ID, chosen from the care record on reaching Adult
+    StageSince  time.Time
+    CareTotal   int // Sum of per-tick wellbeing samples, see careQuality
+    CareSamples int
+
+    Illness       Illness // IllnessNone when healthy
+    SickSince     time.Time
+    StarvingTicks int // Consecutive ticks spent in each stat's bad zone
+    GloomyTicks   int
+    DrainedTicks  int
+
+    Hardcore     bool // Long neglect can kill a hardcore pet
+    FailingTicks int  // Consecutive ticks spent at zero Health
+    DiedAt       time.Time
+    CauseOfDeath string
+
+    Messes      []Mess // Uncleaned messes on the canvas
+    DigestTicks int    // Ticks until the last meal becomes a mess
+
+    Inventory   map[string]int // Catalog item ID -> how many the pet owns
+    RestockDay  string         // Day (YYYY-MM-DD) staples were last restocked
+    TreatDay    string         // Day TreatsToday counts for
+    TreatsToday int
+
+    Asleep         bool // Sleeping pets regenerate Energy every tick
+    SleepStartedAt time.Time
+
+    History []Event // Append-only care history, oldest first
+
+    Achievements map[string]time.Time // Achievement ID -> when it was unlocked
+
+    Coins         int           // Spent in the Shop; only changed by transact
+    Ledger        []Transaction // Every earn and spend, oldest first
+    GoodCareTicks int           // Consecutive well-cared-for ticks towards the next reward
+    Wearing       string        // ID of the cosmetic the pet has on, if any
+
+    Skills map[string]int // Trick ID -> skill level; missing means not learned
+
+    Traits []string // Personality trait IDs, rolled when the pet is created
+
+    EventsFired map[string]time.Time // World event ID -> when it last fired, for cooldowns
+
+    Calls    []AttentionCall // Open attention calls, see checkCalls
+    Mistakes int             // Care mistakes: calls left unanswered past the grace period
+
+    sim *Sim // clock and randomness; not persisted
 }
 
 // NewBitBuddy creates a new BitBuddy with default stats.
-func NewBitBuddy(name string) *BitBuddy {
-    return &BitBuddy{
-        Name:      name,
-        PetType:   "Cat",
-        Hunger:    50,
-        Happiness: 50,
-        Energy:    50,
-        CreatedAt: time.Now(),
-        UpdatedAt: time.Now(),
+func NewBitBuddy(sim *Sim, name string) *BitBuddy {
+    now := sim.Now()
+    start := sim.Rules.Start
+    b := &BitBuddy{
+        Name:       name,
+        PetType:    defaultPetType,
+        Hunger:     start.Hunger,
+        Happiness:  start.Happiness,
+        Energy:     start.Energy,
+        Health:     maxStat,
+        Hygiene:    maxStat,
+        Weight:     start.Weight,
+        CreatedAt:  now,
+        UpdatedAt:  now,
+        Stage:      StageEgg,
+        StageSince: now,
+        Traits:     sim.rollTraits(),
+        sim:        sim,
     }
+    b.restock()
+    b.openWallet()
+    return b
 }
 
-// Feed decreases hunger and slightly increases happiness.
-func (b *BitBuddy) Feed() {
-	b.Hunger -= 20
-	if b.Hunger < minStat {
-		b.Hunger = minStat
-	}
-	b.Happiness += 5
-	if b.Happiness > maxStat {
-		b.Happiness = maxStat
+// attach connects a loaded BitBuddy to the simulation context.
+func (b *BitBuddy) attach(sim *Sim) {
+	b.sim = sim
+}
+
+// species returns the definition for the pet's type.
+func (b *BitBuddy) species() *Species {
+	return b.sim.Species.Get(b.PetType)
+}
+
+// rules returns the game-balance rules in effect.
+func (b *BitBuddy) rules() *Rules {
+	return b.sim.Rules
+}
+
+// now returns the current simulation time.
+func (b *BitBuddy) now() time.Time {
+	return b.sim.Now()
+}
+
+// Rename gives the pet a new name.
+func (b *BitBuddy) Rename(name string) {
+	before := b.snapshot()
+	old := b.Name
+	b.Name = name
+	b.UpdatedAt = b.now()
+	b.recordAction("rename", fmt.Sprintf("Renamed %s to %s", old, name), before)
+}
+
+// SetPetType switches the pet to another species.
+func (b *BitBuddy) SetPetType(petType string) {
+	before := b.snapshot()
+	b.PetType = petType
+	if b.Form != "" {
+		// Forms belong to a species, so the new one picks its own
+		b.evolve()
 	}
-	b.UpdatedAt = time.Now()
+	b.UpdatedAt = b.now()
+	b.recordAction("species", "Became a "+petType, before)
 }
 
-// Play increases happiness but uses energy.
-func (b *BitBuddy) Play() {
-	b.Happiness += 20
+// Play applies the result of a minigame: it increases happiness, more so
+// the better the score (0-100), uses energy and earns coins. It returns the
+// coins earned.
+func (b *BitBuddy) Play(game string, score int) int {
+	before := b.snapshot()
+	play := b.rules().Play
+	traits := b.traitMultipliers()
+	score = clampStat(score)
+	happiness := play.Happiness * (50 + score/2) / 100
+	b.Happiness += scale(happiness, traits.PlayHappiness)
 	if b.Happiness > maxStat {
 		b.Happiness = maxStat
 	}
-	b.Energy -= 15
+	energy := scale(play.Energy, b.multipliers().Energy)
+	b.Energy -= scale(energy, traits.PlayEnergy)
 	if b.Energy < minStat {
 		b.Energy = minStat
 	}
-	b.UpdatedAt = time.Now()
-}
-
-// Sleep restores energy.
-func (b *BitBuddy) Sleep() {
-	b.Energy += 40
-	if b.Energy > maxStat {
-		b.Energy = maxStat
+	b.Weight = clampWeight(b.Weight - play.Weight)
+	detail := fmt.Sprintf("Played %s (score %d)", game, score)
+	if toy, ok := b.favoriteToy(); ok {
+		b.Happiness = clampStat(b.Happiness + toy.Happiness)
+		b.Energy = clampStat(b.Energy + toy.Energy)
+		detail += " with the " + strings.ToLower(toy.Name)
+	}
+	coins := b.rules().Economy.GameCoins * score / 100
+	if coins > 0 {
+		b.transact(coins, "played "+game)
 	}
-	b.UpdatedAt = time.Now()
+	b.UpdatedAt = b.now()
+	b.recordAction("play", detail, before)
+	return coins
 }
 
 // UpdateStats is called on a timer to degrade stats over time.
 func (b *BitBuddy) UpdateStats() {
-	b.Hunger += 2
+	b.decay()
+	b.UpdatedAt = b.now()
+}
+
+// decay applies a single tick of stat degradation without touching UpdatedAt,
+// so it can be replayed for time that passed while the program was closed.
+func (b *BitBuddy) decay() {
+	if b.IsDead() {
+		return
+	}
+	b.restock()
+	rates := b.rules().stage(b.Stage)
+	mult := b.multipliers()
+	traits := b.traitMultipliers()
+	factor := 1
+	if b.IsSick() {
+		factor = b.rules().Health.SickDecayFactor
+	}
+	hunger := scale(scale(rates.HungerDecay, mult.Hunger), traits.HungerDecay) * factor
+	happiness := scale(scale(rates.HappinessDecay, mult.Happiness), traits.HappinessDecay) * factor
+	if b.Asleep {
+		// Sleeping pets get hungry more slowly and don't get bored
+		sleep := b.rules().Sleep
+		hunger = hunger * sleep.HungerPercent / 100
+		happiness = happiness * sleep.HappinessPercent / 100
+	}
+	b.Hunger += hunger
 	if b.Hunger > maxStat {
 		b.Hunger = maxStat
 	}
-	b.Happiness -= 2
+	b.Happiness -= happiness
 	if b.Happiness < minStat {
 		b.Happiness = minStat
 	}
-	b.UpdatedAt = time.Now()
+	if b.Stage != StageEgg {
+		if b.Asleep {
+			b.rest()
+		} else {
+			drain := scale(b.rules().Sleep.AwakeEnergyDrain, traits.EnergyDrain)
+			b.Energy = clampStat(b.Energy - drain)
+		}
+		b.checkHygiene()
+		b.checkWeight()
+		b.checkHealth()
+		if !b.Asleep {
+			b.checkCalls()
+		}
+		b.earnForCare()
+		b.checkDeath()
+	}
+	b.sampleCare()
 }
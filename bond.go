@@ -0,0 +1,79 @@
+package main
+
+import "time"
+
+// careActions are the history actions that count as looking after the pet
+// for Bond. Waking up on its own, renaming and shopping don't.
+var careActions = map[string]bool{
+	"feed":     true,
+	"play":     true,
+	"clean":    true,
+	"medicine": true,
+	"sleep":    true,
+	"train":    true,
+	"trick":    true,
+}
+
+// Bond is how attached the pet is to its owner, 0-100. It is not stored:
+// it is replayed from the care history, growing for every day with some
+// care and dropping for every day in a gap without any, including the gap
+// up to UpdatedAt, and for every care mistake.
+func (b *BitBuddy) Bond() int {
+	rules := b.rules().Bond
+	bond := rules.Start
+	var last time.Time // last day with care
+	for _, e := range b.History {
+		if e.Action == "care-mistake" {
+			bond = clampStat(bond - rules.MistakePenalty)
+			continue
+		}
+		if e.Kind != EventAction || !careActions[e.Action] {
+			continue
+		}
+		day := dayOf(e.At)
+		if !last.IsZero() {
+			if day.Equal(last) {
+				continue
+			}
+			bond = clampStat(bond - daysMissed(last, day)*rules.GapPenalty)
+		}
+		bond = clampStat(bond + rules.PerCareDay)
+		last = day
+	}
+	if !last.IsZero() {
+		bond = clampStat(bond - daysMissed(last, dayOf(b.UpdatedAt))*rules.GapPenalty)
+	}
+	return bond
+}
+
+// Greeting is what a close pet says when its owner comes back, or "" if
+// the bond isn't strong enough for a welcome.
+func (b *BitBuddy) Greeting() string {
+	if b.IsDead() || b.Stage == StageEgg || b.Bond() < b.rules().Bond.GreetAbove {
+		return ""
+	}
+	return b.Line("greet", b.Name+" is happy to see you!")
+}
+
+// RefusesPlay decides whether a pet that doesn't trust its owner yet turns
+// down a game. Refusals are logged so the history explains them.
+func (b *BitBuddy) RefusesPlay() bool {
+	rules := b.rules().Bond
+	if b.Bond() >= rules.RefuseBelow || b.sim.Rand.Intn(100) >= rules.RefuseChance {
+		return false
+	}
+	b.record(EventAction, "refused-play", "Didn't feel like playing")
+	return true
+}
+
+// dayOf is the calendar day t falls on, in local time, as a UTC midnight so
+// days can be compared and subtracted exactly.
+func dayOf(t time.Time) time.Time {
+	y, m, d := t.Local().Date()
+	return time.Date(y, m, d, 0, 0, 0, 0, time.UTC)
+}
+
+// daysMissed is how many whole days lie strictly between two days.
+func daysMissed(from, to time.Time) int {
+	return max(0, int(to.Sub(from).Hours()/24)-1)
+}
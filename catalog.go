@@ -0,0 +1,145 @@
+package main
+
+import (
+	"embed"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// defaultCatalog holds every item the pet can eat, use or wear, along with
+// its price in the Shop.
+//
+//go:embed catalog/catalog.json
+var defaultCatalog embed.FS
+
+// ItemKind is what an item is for.
+type ItemKind string
+
+const (
+	KindFood     ItemKind = "food"     // eaten with Feed; used up
+	KindToy      ItemKind = "toy"      // makes Play more fun; bought once
+	KindMedicine ItemKind = "medicine" // used up by the Medicine action
+	KindCosmetic ItemKind = "cosmetic" // worn by the pet; bought once
+)
+
+// itemKinds is the order the Shop lists kinds in.
+var itemKinds = []ItemKind{KindFood, KindToy, KindMedicine, KindCosmetic}
+
+// Item is one entry in the catalog. For food the effects are applied when
+// it is eaten; for toys they are added to every Play.
+type Item struct {
+	ID        string   `json:"id"`
+	Name      string   `json:"name"`
+	Kind      ItemKind `json:"kind"`
+	Price     int      `json:"price"`
+	Hunger    int      `json:"hunger,omitempty"`
+	Happiness int      `json:"happiness,omitempty"`
+	Energy    int      `json:"energy,omitempty"`
+	Weight    int      `json:"weight,omitempty"`
+	Health    int      `json:"health,omitempty"`
+	Starter   int      `json:"starter,omitempty"` // how many a new pet owns
+	Staple    bool     `json:"staple,omitempty"`  // restocked to Starter every day
+	Art       string   `json:"art,omitempty"`     // cosmetics: drawn above the pet
+}
+
+// Reusable reports whether owning one is enough: toys and cosmetics are
+// bought once and never used up.
+func (i Item) Reusable() bool {
+	return i.Kind == KindToy || i.Kind == KindCosmetic
+}
+
+// Catalog is the list of items, in file order.
+type Catalog struct {
+	items []Item
+	byID  map[string]Item
+}
+
+// loadCatalog parses the embedded catalog.
+func loadCatalog() *Catalog {
+	return mustParse(defaultCatalog, "catalog/catalog.json", parseCatalog)
+}
+
+// parseCatalog decodes and validates the catalog.
+func parseCatalog(data []byte) (*Catalog, error) {
+	var items []Item
+	if err := json.Unmarshal(data, &items); err != nil {
+		return nil, err
+	}
+	c := &Catalog{byID: make(map[string]Item)}
+	var errs []error
+	for _, it := range items {
+		switch {
+		case it.ID == "" || it.Name == "":
+			errs = append(errs, fmt.Errorf("item %q: id and name are required", it.ID))
+			continue
+		case c.byID[it.ID].ID != "":
+			errs = append(errs, fmt.Errorf("item %q: duplicate id", it.ID))
+			continue
+		}
+		if !containsKind(itemKinds, it.Kind) {
+			errs = append(errs, fmt.Errorf("item %q: unknown kind %q", it.ID, it.Kind))
+		}
+		if it.Price < 0 || it.Starter < 0 {
+			errs = append(errs, fmt.Errorf("item %q: price and starter must not be negative", it.ID))
+		}
+		if it.Kind == KindCosmetic && it.Art == "" {
+			errs = append(errs, fmt.Errorf("item %q: cosmetics need art", it.ID))
+		}
+		c.items = append(c.items, it)
+		c.byID[it.ID] = it
+	}
+	return c, errors.Join(errs...)
+}
+
+func containsKind(kinds []ItemKind, k ItemKind) bool {
+	for _, v := range kinds {
+		if v == k {
+			return true
+		}
+	}
+	return false
+}
+
+// Get looks an item up by ID.
+func (c *Catalog) Get(id string) (Item, bool) {
+	it, ok := c.byID[id]
+	return it, ok
+}
+
+// Items lists every item in Shop order: grouped by kind, then file order.
+func (c *Catalog) Items() []Item {
+	var out []Item
+	for _, k := range itemKinds {
+		out = append(out, c.Kind(k)...)
+	}
+	return out
+}
+
+// Kind lists the items of one kind, in file order.
+func (c *Catalog) Kind(k ItemKind) []Item {
+	var out []Item
+	for _, it := range c.items {
+		if it.Kind == k {
+			out = append(out, it)
+		}
+	}
+	return out
+}
+
+// itemEffects summarises an item's stat effects, e.g. "Hun-20 Hap+5".
+func itemEffects(it Item) string {
+	var parts []string
+	add := func(label string, v int) {
+		if v != 0 {
+			parts = append(parts, fmt.Sprintf("%s%+d", label, v))
+		}
+	}
+	add("Hun", it.Hunger)
+	add("Hap", it.Happiness)
+	add("Eng", it.Energy)
+	add("Wt", it.Weight)
+	add("HP", it.Health)
+	return strings.Join(parts, " ")
+}
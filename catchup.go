@@ -0,0 +1,126 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// awayReport summarises the decay replayed by CatchUp.
+type awayReport struct {
+	Elapsed   time.Duration
+	Ticks     int
+	Hunger    int // change in Hunger
+	Happiness int // change in Happiness
+	Energy    int // change in Energy
+	Health    int // change in Health
+	FellIll   Illness
+}
+
+// CatchUp replays the stat decay for the time that passed between UpdatedAt
+// and now. Only catch_up.max_away of absence counts, and at most
+// catch_up.max_ticks ticks are replayed (death.catch_up_ticks for hardcore
+// pets): live decay is tuned for an open terminal, so replaying every tick of
+// a long absence would always leave the pet at the extremes. It returns nil
+// when too little time passed to matter (or the clock went backwards).
+func (b *BitBuddy) CatchUp(now time.Time) *awayReport {
+	rules := b.rules()
+	elapsed := now.Sub(b.UpdatedAt)
+	if b.UpdatedAt.IsZero() || elapsed < rules.Tick.Duration {
+		return nil
+	}
+	counted := elapsed
+	if counted > rules.CatchUp.MaxAway.Duration {
+		counted = rules.CatchUp.MaxAway.Duration
+	}
+	ticks := int(counted / rules.Tick.Duration)
+	limit := rules.CatchUp.MaxTicks
+	if b.isHardcore() {
+		limit = rules.Death.CatchUpTicks
+	}
+	if ticks > limit {
+		ticks = limit
+	}
+
+	hunger, happiness, energy, health, wasSick := b.Hunger, b.Happiness, b.Energy, b.Health, b.IsSick()
+	before := b.snapshot()
+	// Each replayed tick happens at its own time, so calls can run out their
+	// grace period and deaths, illnesses, messes and history entries are
+	// stamped when they happened rather than at load time
+	live := b.sim.Clock
+	replay := NewManualClock(b.UpdatedAt)
+	b.sim.Clock = replay
+	for i := 0; i < ticks && !b.IsDead(); i++ {
+		replay.Advance(rules.Tick.Duration)
+		b.decay()
+	}
+	b.sim.Clock = live
+	// Nobody answered the calls still open for the rest of the absence,
+	// unless the pet died or fell asleep and stopped calling
+	if !b.IsDead() && !b.Asleep {
+		b.expireCalls(now)
+	}
+	b.UpdatedAt = now
+
+	report := &awayReport{
+		Elapsed:   elapsed,
+		Ticks:     ticks,
+		Hunger:    b.Hunger - hunger,
+		Happiness: b.Happiness - happiness,
+		Energy:    b.Energy - energy,
+		Health:    b.Health - health,
+	}
+	if !wasSick && b.IsSick() {
+		report.FellIll = b.Illness
+	}
+	b.History = append(b.History, Event{
+		At:      now,
+		Kind:    EventStat,
+		Action:  "away",
+		Detail:  "Alone for " + formatDuration(elapsed),
+		Changes: before.changes(b),
+	})
+	return report
+}
+
+// String renders the report as the "while you were away" line in the TUI.
+func (r *awayReport) String() string {
+	if r == nil {
+		return ""
+	}
+	var changes []string
+	if r.Hunger != 0 {
+		changes = append(changes, fmt.Sprintf("Hunger %+d", r.Hunger))
+	}
+	if r.Happiness != 0 {
+		changes = append(changes, fmt.Sprintf("Happiness %+d", r.Happiness))
+	}
+	if r.Energy != 0 {
+		changes = append(changes, fmt.Sprintf("Energy %+d", r.Energy))
+	}
+	if r.Health != 0 {
+		changes = append(changes, fmt.Sprintf("Health %+d", r.Health))
+	}
+	if r.FellIll != IllnessNone {
+		changes = append(changes, "caught "+strings.ToLower(string(r.FellIll)))
+	}
+	if len(changes) == 0 {
+		changes = append(changes, "nothing changed")
+	}
+	return fmt.Sprintf("While you were away (%s): %s",
+		formatDuration(r.Elapsed), strings.Join(changes, ", "))
+}
+
+// formatDuration prints a duration in the coarse units a person thinks in.
+func formatDuration(d time.Duration) string {
+	switch {
+	case d >= 24*time.Hour:
+		return fmt.Sprintf("%dd %dh", int(d.Hours())/24, int(d.Hours())%24)
+	case d >= time.Hour:
+		return fmt.Sprintf("%dh %dm", int(d.Hours()), int(d.Minutes())%60)
+	case d >= time.Minute:
+		return fmt.Sprintf("%dm", int(d.Minutes()))
+	default:
+		return fmt.Sprintf("%ds", int(d.Seconds()))
+	}
+}
@@ -0,0 +1,104 @@
+package main
+
+import (
+	"testing"
+	"time"
+)
+
+// A hardcore pet left alone long enough dies during CatchUp, and the calls
+// it made while alone count as care mistakes at the times they ran out.
+func TestCatchUpReplaysTime(t *testing.T) {
+	start := time.Date(2026, 3, 1, 9, 0, 0, 0, time.UTC)
+	clock := NewManualClock(start)
+	sim := NewSim(clock, 7)
+	rules, err := loadPreset("hardcore")
+	if err != nil {
+		t.Fatal(err)
+	}
+	sim.Rules = rules
+	b := NewBitBuddy(sim, "Alone")
+	b.Stage = StageAdult
+	b.Hardcore = true
+
+	clock.Advance(72 * time.Hour)
+	b.CatchUp(clock.Now())
+
+	if !b.IsDead() {
+		t.Fatal("pet survived 72h of neglect")
+	}
+	if b.CareMistakes() == 0 {
+		t.Error("no care mistakes counted while away")
+	}
+	if !b.DiedAt.After(start) || !b.DiedAt.Before(clock.Now()) {
+		t.Errorf("DiedAt = %v, want during the absence", b.DiedAt)
+	}
+	if b.UpdatedAt != clock.Now() {
+		t.Errorf("UpdatedAt = %v, want %v", b.UpdatedAt, clock.Now())
+	}
+	if sim.Now() != clock.Now() {
+		t.Error("CatchUp left the replay clock in place")
+	}
+	for _, e := range b.History {
+		if e.Action == "call" && !e.At.Before(clock.Now()) {
+			t.Fatalf("call recorded at load time %v", e.At)
+		}
+	}
+}
+
+// In the normal preset the replay stops long before a call's grace runs
+// out, so the calls still open afterwards count as mistakes when their
+// grace ran out, and only if it ran out before the player came back.
+func TestCatchUpExpiresCalls(t *testing.T) {
+	for _, away := range []time.Duration{2 * time.Minute, 2 * time.Hour} {
+		t.Run(away.String(), func(t *testing.T) {
+			start := time.Date(2026, 3, 1, 9, 0, 0, 0, time.UTC)
+			clock := NewManualClock(start)
+			sim := NewSim(clock, 7)
+			rules, err := loadPreset("normal")
+			if err != nil {
+				t.Fatal(err)
+			}
+			sim.Rules = rules
+			grace := rules.Attention.Grace.Duration
+			if time.Duration(rules.CatchUp.MaxTicks)*rules.Tick.Duration >= grace {
+				t.Fatal("the normal preset replays past the grace period, so this no longer tests expireCalls")
+			}
+			b := NewBitBuddy(sim, "Peckish")
+			b.Stage = StageAdult
+			b.Hunger = rules.Attention.HungerAbove
+
+			clock.Advance(away)
+			b.CatchUp(clock.Now())
+
+			if b.callFor("hunger") == nil {
+				t.Fatal("no hunger call open")
+			}
+			missed := 0
+			for _, c := range b.Calls {
+				if late := away >= grace; c.Missed != late {
+					t.Errorf("%s call Missed = %v after %v away", c.Reason, c.Missed, away)
+				}
+				if c.Missed {
+					missed++
+				}
+			}
+			if b.CareMistakes() != missed {
+				t.Errorf("CareMistakes = %d, want %d", b.CareMistakes(), missed)
+			}
+			var stamps []time.Time
+			for _, e := range b.History {
+				if e.Action == "care-mistake" {
+					stamps = append(stamps, e.At)
+				}
+			}
+			if len(stamps) != missed {
+				t.Fatalf("%d care-mistake events for %d missed calls", len(stamps), missed)
+			}
+			for i, c := range b.Calls {
+				if c.Missed && !stamps[i].Equal(c.Since.Add(grace)) {
+					t.Errorf("%s mistake recorded at %v, want when its grace ran out at %v", c.Reason, stamps[i], c.Since.Add(grace))
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,18 @@
+package main
+
+import "embed"
+
+// mustParse reads name from the embedded data and parses it. Embedded files
+// are part of the binary, so a failure here is a build problem and panics;
+// embedded_test.go parses every one of them so go test catches it first.
+func mustParse[T any](fsys embed.FS, name string, parse func([]byte) (T, error)) T {
+	data, err := fsys.ReadFile(name)
+	if err != nil {
+		panic("bitbuddy: embedded " + name + ": " + err.Error())
+	}
+	v, err := parse(data)
+	if err != nil {
+		panic("bitbuddy: embedded " + name + ": " + err.Error())
+	}
+	return v
+}
@@ -0,0 +1,42 @@
+package main
+
+import (
+	"embed"
+	"io/fs"
+	"testing"
+)
+
+// Every embedded data file parses and validates, so a bad edit fails here
+// rather than panicking at startup.
+func TestEmbeddedData(t *testing.T) {
+	check := func(fsys embed.FS, parse func([]byte) error) {
+		t.Helper()
+		err := fs.WalkDir(fsys, ".", func(path string, d fs.DirEntry, err error) error {
+			if err != nil || d.IsDir() {
+				return err
+			}
+			data, err := fsys.ReadFile(path)
+			if err != nil {
+				return err
+			}
+			if err := parse(data); err != nil {
+				t.Errorf("%s: %v", path, err)
+			}
+			return nil
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	check(defaultAchievements, func(data []byte) error { _, err := parseAchievements(data); return err })
+	check(defaultCatalog, func(data []byte) error { _, err := parseCatalog(data); return err })
+	check(defaultTricks, func(data []byte) error { _, err := parseTricks(data); return err })
+	check(defaultTraits, func(data []byte) error { _, err := parseTraits(data); return err })
+	check(defaultWorldEvents, func(data []byte) error { _, err := parseWorldEvents(data); return err })
+	check(rulePresets, func(data []byte) error { _, err := parseRules(data); return err })
+
+	r := &SpeciesRegistry{byName: make(map[string]*Species)}
+	if err := r.loadFS(defaultSpecies, "species"); err != nil {
+		t.Errorf("species: %v", err)
+	}
+}
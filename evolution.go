@@ -0,0 +1,156 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Form is one adult form of a species. Which form a pet grows into is
+// decided once, when it becomes an Adult, by the first form in the species
+// file whose condition matches its upbringing; the last form should have an
+// empty condition so there is always a match.
+type Form struct {
+	ID          string          `json:"id"`
+	Name        string          `json:"name"`
+	Description string          `json:"description"`
+	When        EvolveCondition `json:"when"`
+	// Frames replaces the species art for the states it lists; missing
+	// states (or no frames at all) use the species art.
+	Frames map[string][][]string `json:"frames,omitempty"`
+	// Multipliers is the form's stat profile. Zero fields keep the species'
+	// multiplier.
+	Multipliers SpeciesMultipliers `json:"multipliers"`
+}
+
+// EvolveCondition is a set of bounds on a CareRecord; all of them must
+// hold. Unset fields don't constrain anything.
+type EvolveCondition struct {
+	MinCare     int  `json:"min_care,omitempty"`
+	MaxCare     *int `json:"max_care,omitempty"`
+	MinMistakes int  `json:"min_mistakes,omitempty"`
+	MaxMistakes *int `json:"max_mistakes,omitempty"`
+	MinTraining int  `json:"min_training,omitempty"`
+}
+
+// CareRecord sums up how a pet was raised. It is everything evolution looks
+// at. Care and Mistakes come from the pet's running counters (CareTotal,
+// CareSamples and Mistakes), not from its History.
+type CareRecord struct {
+	Care     int // average wellbeing, 0-100, see careQuality
+	Mistakes int // see CareMistakes
+	Training int // total trick levels learned
+}
+
+func (r CareRecord) String() string {
+	return fmt.Sprintf("care %d, %d mistakes, training %d", r.Care, r.Mistakes, r.Training)
+}
+
+// Matches reports whether r satisfies every bound in c.
+func (c EvolveCondition) Matches(r CareRecord) bool {
+	return r.Care >= c.MinCare &&
+		(c.MaxCare == nil || r.Care <= *c.MaxCare) &&
+		r.Mistakes >= c.MinMistakes &&
+		(c.MaxMistakes == nil || r.Mistakes <= *c.MaxMistakes) &&
+		r.Training >= c.MinTraining
+}
+
+// Evolve picks the adult form for a pet raised as r, or nil if the species
+// has no forms.
+func (s *Species) Evolve(r CareRecord) *Form {
+	for i := range s.Forms {
+		if s.Forms[i].When.Matches(r) {
+			return &s.Forms[i]
+		}
+	}
+	return nil
+}
+
+// form looks up a form by ID, or nil.
+func (s *Species) form(id string) *Form {
+	for i := range s.Forms {
+		if s.Forms[i].ID == id {
+			return &s.Forms[i]
+		}
+	}
+	return nil
+}
+
+// validateForms checks form IDs are unique, their art only uses known
+// states and the last form catches every pet.
+func (s *Species) validateForms() error {
+	seen := make(map[string]bool)
+	for _, f := range s.Forms {
+		if f.ID == "" || f.Name == "" {
+			return fmt.Errorf("form %q: id and name are required", f.ID)
+		}
+		if seen[f.ID] {
+			return fmt.Errorf("form %q: duplicate id", f.ID)
+		}
+		seen[f.ID] = true
+		for state, frames := range f.Frames {
+			if !contains(speciesStates, state) || len(frames) == 0 {
+				return fmt.Errorf("form %q: bad %q frames", f.ID, state)
+			}
+		}
+	}
+	if n := len(s.Forms); n > 0 && s.Forms[n-1].When != (EvolveCondition{}) {
+		return fmt.Errorf("last form %q must have an empty condition", s.Forms[n-1].ID)
+	}
+	return nil
+}
+
+// CareRecord sums up the pet's upbringing so far.
+func (b *BitBuddy) CareRecord() CareRecord {
+	training := 0
+	for _, level := range b.Skills {
+		training += level
+	}
+	return CareRecord{Care: b.careQuality(), Mistakes: b.CareMistakes(), Training: training}
+}
+
+// form is the pet's adult form, or nil before it evolves or if its species
+// has no forms.
+func (b *BitBuddy) form() *Form {
+	if b.Form == "" {
+		return nil
+	}
+	return b.species().form(b.Form)
+}
+
+// multipliers is the pet's stat profile: its form's, falling back to its
+// species' for anything the form leaves unset.
+func (b *BitBuddy) multipliers() SpeciesMultipliers {
+	m := b.species().Multipliers
+	if f := b.form(); f != nil {
+		if f.Multipliers.Hunger != 0 {
+			m.Hunger = f.Multipliers.Hunger
+		}
+		if f.Multipliers.Happiness != 0 {
+			m.Happiness = f.Multipliers.Happiness
+		}
+		if f.Multipliers.Energy != 0 {
+			m.Energy = f.Multipliers.Energy
+		}
+	}
+	return m
+}
+
+// evolve settles the pet's adult form from its care record so far.
+func (b *BitBuddy) evolve() {
+	record := b.CareRecord()
+	f := b.species().Evolve(record)
+	if f == nil {
+		b.Form = ""
+		return
+	}
+	b.Form = f.ID
+	b.record(EventStage, "evolved", fmt.Sprintf("Became a %s (%s)", strings.ToLower(f.Name), record))
+}
+
+// FormName is the name of the pet's adult form, or "" if it has none.
+func (b *BitBuddy) FormName() string {
+	if f := b.form(); f != nil {
+		return f.Name
+	}
+	return ""
+}
@@ -0,0 +1,61 @@
+package main
+
+import (
+	"testing"
+	"time"
+)
+
+// The shipped species share one shape of form conditions, listed here by
+// position: a well-loved pet, a trained one, a neglected one, and the rest.
+func TestEvolveShippedForms(t *testing.T) {
+	forms := map[string][]string{
+		"Cat":   {"lion", "ninja", "alley", "house"},
+		"Bunny": {"angora", "acrobat", "wild", "pet-bunny"},
+		"Corgi": {"royal", "herder", "scrappy", "good-dog"},
+	}
+	tests := []struct {
+		name   string
+		record CareRecord
+		want   int
+	}{
+		{"well loved", CareRecord{Care: 80, Mistakes: 0}, 0},
+		{"well loved, a couple of slips", CareRecord{Care: 75, Mistakes: 2}, 0},
+		{"well loved but careless", CareRecord{Care: 90, Mistakes: 3}, 3},
+		{"loved and trained", CareRecord{Care: 80, Mistakes: 1, Training: 9}, 0},
+		{"trained", CareRecord{Care: 60, Training: 6}, 1},
+		{"trained despite neglect", CareRecord{Care: 20, Mistakes: 8, Training: 6}, 1},
+		{"neglected", CareRecord{Care: 30, Mistakes: 6}, 2},
+		{"average", CareRecord{Care: 50, Mistakes: 3, Training: 2}, 3},
+		{"nothing recorded", CareRecord{}, 3},
+	}
+	sim := NewSim(nil, 1)
+	for species, ids := range forms {
+		s := sim.Species.Get(species)
+		if len(s.Forms) != len(ids) {
+			t.Fatalf("%s has %d forms, want %d", species, len(s.Forms), len(ids))
+		}
+		for _, tt := range tests {
+			f := s.Evolve(tt.record)
+			if f == nil || f.ID != ids[tt.want] {
+				t.Errorf("%s, %s (%v): got %v, want %s", species, tt.name, tt.record, f, ids[tt.want])
+			}
+		}
+	}
+}
+
+// A pet's form follows from the care it was given.
+func TestEvolveFromCare(t *testing.T) {
+	clock := NewManualClock(time.Date(2026, 3, 1, 9, 0, 0, 0, time.UTC))
+	sim := NewSim(clock, 1)
+	b := NewBitBuddy(sim, "Grown")
+	b.CareTotal, b.CareSamples = 85*100, 100
+	b.Mistakes = 1
+	b.Stage = StageAdult
+	b.evolve()
+	if b.Form != "lion" {
+		t.Fatalf("form = %q, want lion", b.Form)
+	}
+	if e, ok := b.LastEvent("evolved"); !ok || e.Kind != EventStage {
+		t.Errorf("no evolved event recorded: %+v", e)
+	}
+}
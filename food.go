@@ -0,0 +1,106 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+)
+
+const (
+	minWeight = 5
+	maxWeight = 99
+)
+
+// Feed gives the pet one item of food from the inventory and applies its
+// effects. Treats past the daily treat limit add extra weight and can bring
+// on a stomach ache.
+func (b *BitBuddy) Feed(id string) (Item, error) {
+	food, ok := b.sim.Catalog.Get(id)
+	if !ok || food.Kind != KindFood {
+		return food, fmt.Errorf("unknown food %q", id)
+	}
+	if b.Inventory[id] <= 0 {
+		return food, fmt.Errorf("out of %s", food.Name)
+	}
+	before := b.snapshot()
+	b.Inventory[id]--
+
+	traits := b.traitMultipliers()
+	b.Hunger = clampStat(b.Hunger + scale(food.Hunger, traits.FoodHunger))
+	b.Happiness = clampStat(b.Happiness + scale(food.Happiness, traits.FoodHappiness))
+	b.Health = clampStat(b.Health + food.Health)
+	b.Weight += food.Weight
+
+	rules := b.rules().Feed
+	if id == "treat" {
+		today := b.now().Format("2006-01-02")
+		if b.TreatDay != today {
+			b.TreatDay = today
+			b.TreatsToday = 0
+		}
+		b.TreatsToday++
+		if b.OverTreated() {
+			b.Weight += rules.TreatOverWeight
+			if !b.IsSick() && b.sim.Rand.Intn(100) < rules.TreatSickChance {
+				b.fallIll(IllnessStomachAche)
+			}
+		}
+	}
+	b.Weight = clampWeight(b.Weight)
+	b.DigestTicks = rules.DigestTicks
+	b.UpdatedAt = b.now()
+	b.recordAction("feed", "Fed a "+strings.ToLower(food.Name), before)
+	return food, nil
+}
+
+// restock hands a new pet its starter items, then tops the catalog's daily
+// staples back up to their starter count once per calendar day.
+func (b *BitBuddy) restock() {
+	today := b.now().Format("2006-01-02")
+	if b.RestockDay == today {
+		return
+	}
+	b.RestockDay = today
+	if b.Inventory == nil {
+		b.Inventory = make(map[string]int)
+		for _, it := range b.sim.Catalog.Items() {
+			if it.Starter > 0 {
+				b.Inventory[it.ID] = it.Starter
+			}
+		}
+		return
+	}
+	for _, it := range b.sim.Catalog.Items() {
+		if it.Staple && b.Inventory[it.ID] < it.Starter {
+			b.Inventory[it.ID] = it.Starter
+		}
+	}
+}
+
+// OverTreated reports whether the pet has had more treats today than is good
+// for it.
+func (b *BitBuddy) OverTreated() bool {
+	return b.TreatDay == b.now().Format("2006-01-02") && b.TreatsToday > b.rules().Feed.TreatLimit
+}
+
+// IsOverweight reports whether the pet is heavy enough to hurt its Health.
+func (b *BitBuddy) IsOverweight() bool {
+	return b.Weight >= b.rules().Weight.Heavy
+}
+
+// checkWeight runs once per tick: an overweight pet slowly loses Health.
+func (b *BitBuddy) checkWeight() {
+	if b.IsOverweight() {
+		b.Health = clampStat(b.Health - b.rules().Weight.HeavyDamage)
+	}
+}
+
+// clampWeight keeps Weight within minWeight..maxWeight.
+func clampWeight(w int) int {
+	if w < minWeight {
+		return minWeight
+	}
+	if w > maxWeight {
+		return maxWeight
+	}
+	return w
+}
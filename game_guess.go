@@ -0,0 +1,106 @@
+package main
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+const (
+	guessMax   = 20
+	guessTries = 5
+)
+
+// guessGame is guess-the-number: the pet thinks of a number and says
+// whether each guess is too high or too low.
+type guessGame struct {
+	secret  int
+	input   string
+	tries   int
+	hint    string
+	won     bool
+	over    bool // out of tries or guessed; waiting for a key to finish
+	checked bool // the final screen has been seen
+}
+
+func newGuessGame(sim *Sim) Minigame {
+	return guessGame{secret: 1 + sim.Rand.Intn(guessMax)}
+}
+
+func (g guessGame) Init() tea.Cmd { return nil }
+
+func (g guessGame) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	key, ok := msg.(tea.KeyMsg)
+	if !ok {
+		return g, nil
+	}
+	if g.over {
+		g.checked = true
+		return g, nil
+	}
+	switch key.String() {
+	case "enter":
+		n, err := strconv.Atoi(g.input)
+		g.input = ""
+		if err != nil || n < 1 || n > guessMax {
+			g.hint = fmt.Sprintf("Pick a number from 1 to %d.", guessMax)
+			return g, nil
+		}
+		g.tries++
+		switch {
+		case n == g.secret:
+			g.won, g.over = true, true
+		case g.tries >= guessTries:
+			g.over = true
+		case n < g.secret:
+			g.hint = fmt.Sprintf("%d is too low.", n)
+		default:
+			g.hint = fmt.Sprintf("%d is too high.", n)
+		}
+	case "backspace":
+		if len(g.input) > 0 {
+			g.input = g.input[:len(g.input)-1]
+		}
+	default:
+		if s := key.String(); len(s) == 1 && s[0] >= '0' && s[0] <= '9' && len(g.input) < 2 {
+			g.input += s
+		}
+	}
+	return g, nil
+}
+
+func (g guessGame) View() string {
+	var b strings.Builder
+	b.WriteString(fmt.Sprintf("Guess the number (1-%d)\n\n", guessMax))
+	switch {
+	case g.won:
+		b.WriteString(statusMessageStyle.Render(fmt.Sprintf("Got it in %d! It was %d.", g.tries, g.secret)) + "\n")
+	case g.over:
+		b.WriteString(sickStyle.Render(fmt.Sprintf("Out of tries - it was %d.", g.secret)) + "\n")
+	default:
+		b.WriteString(fmt.Sprintf("Tries left: %d\n", guessTries-g.tries))
+		if g.hint != "" {
+			b.WriteString(g.hint + "\n")
+		}
+		b.WriteString("\n> " + g.input + "_\n")
+	}
+	if g.over {
+		b.WriteString("\n" + quitStyle.Render("Press any key"))
+	} else {
+		b.WriteString("\n" + quitStyle.Render("Type a number, Enter to guess | Esc quit"))
+	}
+	return b.String()
+}
+
+// Result scores a first-try guess 100, losing 20 for every extra try.
+func (g guessGame) Result() (int, bool) {
+	if !g.checked {
+		return 0, false
+	}
+	if !g.won {
+		return 0, true
+	}
+	return 100 - (g.tries-1)*100/guessTries, true
+}
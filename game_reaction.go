@@ -0,0 +1,129 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+const (
+	reactionRounds = 3
+	reactionBest   = 250 * time.Millisecond // scores 100
+	reactionWorst  = time.Second            // scores 0
+)
+
+// reactionGoMsg tells the reaction game to show GO! for a round.
+type reactionGoMsg struct{ round int }
+
+// reactionGame is a reaction-timing game: after a random wait it shows GO!
+// and times how long the player takes to press space.
+type reactionGame struct {
+	sim     *Sim
+	round   int
+	goAt    time.Time // zero until GO! is showing
+	scores  []int
+	last    string
+	checked bool
+}
+
+func newReactionGame(sim *Sim) Minigame {
+	return reactionGame{sim: sim}
+}
+
+func (g reactionGame) Init() tea.Cmd {
+	return g.wait()
+}
+
+// wait schedules GO! for the current round after 1-3 seconds.
+func (g reactionGame) wait() tea.Cmd {
+	round := g.round
+	d := time.Second + time.Duration(g.sim.Rand.Intn(2000))*time.Millisecond
+	return tea.Tick(d, func(time.Time) tea.Msg { return reactionGoMsg{round} })
+}
+
+func (g reactionGame) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case reactionGoMsg:
+		if msg.round == g.round && g.round < reactionRounds {
+			g.goAt = g.sim.Now()
+		}
+	case tea.KeyMsg:
+		if g.round >= reactionRounds {
+			g.checked = true
+			return g, nil
+		}
+		if msg.String() != " " && msg.String() != "enter" {
+			return g, nil
+		}
+		if g.goAt.IsZero() {
+			g.last = "Too soon!"
+			g.scores = append(g.scores, 0)
+		} else {
+			took := g.sim.Now().Sub(g.goAt)
+			g.last = fmt.Sprintf("%d ms", took.Milliseconds())
+			g.scores = append(g.scores, reactionScore(took))
+		}
+		g.round++
+		g.goAt = time.Time{}
+		if g.round < reactionRounds {
+			return g, g.wait()
+		}
+	}
+	return g, nil
+}
+
+// reactionScore maps a reaction time onto 0-100.
+func reactionScore(took time.Duration) int {
+	switch {
+	case took <= reactionBest:
+		return 100
+	case took >= reactionWorst:
+		return 0
+	}
+	return int(100 * (reactionWorst - took) / (reactionWorst - reactionBest))
+}
+
+func (g reactionGame) View() string {
+	var b strings.Builder
+	b.WriteString(fmt.Sprintf("Reaction - round %d/%d\n\n", min(g.round+1, reactionRounds), reactionRounds))
+	switch {
+	case g.round >= reactionRounds:
+		score, _ := g.score()
+		b.WriteString(statusMessageStyle.Render(fmt.Sprintf("Final score: %d", score)) + "\n")
+	case !g.goAt.IsZero():
+		b.WriteString(selectedChoiceStyle.Render("GO!") + "\n")
+	default:
+		b.WriteString("Wait for it...\n")
+	}
+	if g.last != "" {
+		b.WriteString("Last: " + g.last + "\n")
+	}
+	if g.round >= reactionRounds {
+		b.WriteString("\n" + quitStyle.Render("Press any key"))
+	} else {
+		b.WriteString("\n" + quitStyle.Render("Space when you see GO! | Esc quit"))
+	}
+	return b.String()
+}
+
+// score is the average over the rounds played.
+func (g reactionGame) score() (int, bool) {
+	if len(g.scores) == 0 {
+		return 0, false
+	}
+	total := 0
+	for _, s := range g.scores {
+		total += s
+	}
+	return total / len(g.scores), true
+}
+
+func (g reactionGame) Result() (int, bool) {
+	if !g.checked {
+		return 0, false
+	}
+	score, _ := g.score()
+	return score, true
+}
@@ -0,0 +1,132 @@
+package main
+
+import (
+	"encoding/json"
+	"os"
+	"sort"
+	"strings"
+	"time"
+)
+
+// graveyardFile is where FileStore archives every pet that died, beside the
+// saves.
+const graveyardFile = "bitbuddy-graveyard.json"
+
+// Memorial is what the graveyard remembers about a pet that died.
+type Memorial struct {
+	Name    string
+	PetType string
+	Stage   LifeStage
+	BornAt  time.Time
+	DiedAt  time.Time
+	Cause   string
+	// CareMistakes is how many attention calls went unanswered in its life.
+	CareMistakes int
+	Pet          *BitBuddy // full state at the time of death
+}
+
+// Lifespan is how long the pet lived.
+func (m Memorial) Lifespan() time.Duration {
+	return m.DiedAt.Sub(m.BornAt)
+}
+
+// IsDead reports whether the pet has died.
+func (b *BitBuddy) IsDead() bool {
+	return !b.DiedAt.IsZero()
+}
+
+// isHardcore reports whether long neglect can kill this pet, either because
+// it was started with --hardcore or because the rules make every pet hardcore.
+func (b *BitBuddy) isHardcore() bool {
+	return b.Hardcore || b.rules().Death.Enabled
+}
+
+// checkDeath runs once per tick for hardcore pets. A pet that stays at zero
+// Health for death.after_ticks dies.
+func (b *BitBuddy) checkDeath() {
+	if !b.isHardcore() || b.IsDead() {
+		return
+	}
+	b.FailingTicks = streak(b.FailingTicks, b.Health <= minStat)
+	if b.FailingTicks < b.rules().Death.AfterTicks {
+		return
+	}
+	b.DiedAt = b.now()
+	switch {
+	case b.IsSick():
+		b.CauseOfDeath = string(b.Illness)
+	case b.Hunger >= maxStat:
+		b.CauseOfDeath = "Starvation"
+	default:
+		b.CauseOfDeath = "Neglect"
+	}
+	b.record(EventHealth, "died", "Died of "+strings.ToLower(b.CauseOfDeath))
+}
+
+// readGraveyard reads the graveyard file at path. A missing graveyard is
+// empty.
+func readGraveyard(path string) ([]Memorial, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	var graves []Memorial
+	if err := json.Unmarshal(data, &graves); err != nil {
+		return nil, err
+	}
+	return graves, nil
+}
+
+// memorialFor describes a dead pet for the graveyard.
+func memorialFor(b *BitBuddy) Memorial {
+	return Memorial{
+		Name:    b.Name,
+		PetType: b.PetType,
+		Stage:   b.Stage,
+		BornAt:  b.CreatedAt,
+		DiedAt:  b.DiedAt,
+		Cause:   b.CauseOfDeath,
+
+		CareMistakes: b.CareMistakes(),
+		Pet:          b,
+	}
+}
+
+// bury moves a dead pet from the store into its graveyard and returns its
+// memorial.
+func bury(store Store, key string, b *BitBuddy) (Memorial, error) {
+	memorial := memorialFor(b)
+	return memorial, store.Bury(key, memorial)
+}
+
+// writeGraveyard replaces the graveyard file at path with graves.
+func writeGraveyard(path string, graves []Memorial) error {
+	data, err := json.MarshalIndent(graves, "", "  ")
+	if err != nil {
+		return err
+	}
+	return writeFileAtomic(path, data, 0644)
+}
+
+// mergeGraveyards moves the memorials in the graveyard at src into the one
+// at dst, oldest death first, and removes src. A missing src is not an
+// error.
+func mergeGraveyards(src, dst string) error {
+	moved, err := readGraveyard(src)
+	if err != nil || moved == nil {
+		return err
+	}
+	graves, err := readGraveyard(dst)
+	if err != nil {
+		return err
+	}
+	graves = append(graves, moved...)
+	sort.SliceStable(graves, func(i, j int) bool { return graves[i].DiedAt.Before(graves[j].DiedAt) })
+	if err := writeGraveyard(dst, graves); err != nil {
+		return err
+	}
+	return os.Remove(src)
+}
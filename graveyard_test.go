@@ -0,0 +1,40 @@
+package main
+
+import (
+	"errors"
+	"testing"
+)
+
+// A dead pet opened by the owner is archived and then taken out of the
+// store; a spectator shows the memorial but leaves both alone.
+func TestEnterMemorial(t *testing.T) {
+	sim := NewSim(nil, 1)
+	store := NewMemoryStore()
+	buddy := NewBitBuddy(sim, "Ghost")
+	buddy.DiedAt, buddy.CauseOfDeath = sim.Now(), "Neglect"
+	if err := store.Save(defaultPetKey, buddy); err != nil {
+		t.Fatal(err)
+	}
+
+	m := initialModel(sim, spectatorStore{store}, defaultPetKey, buddy)
+	if m.memorial == nil || m.statusMessage != "" {
+		t.Fatalf("spectator memorial = %v, status %q", m.memorial, m.statusMessage)
+	}
+	if graves, _ := store.Graveyard(); len(graves) != 0 {
+		t.Fatalf("spectator archived %d pets", len(graves))
+	}
+	if _, err := store.Load(defaultPetKey); err != nil {
+		t.Fatalf("spectator removed the save: %v", err)
+	}
+
+	m = initialModel(sim, store, defaultPetKey, buddy)
+	if m.memorial == nil || m.statusMessage != "" {
+		t.Fatalf("owner memorial = %v, status %q", m.memorial, m.statusMessage)
+	}
+	if graves, _ := store.Graveyard(); len(graves) != 1 || graves[0].Name != "Ghost" {
+		t.Fatalf("graveyard = %v", graves)
+	}
+	if _, err := store.Load(defaultPetKey); !errors.Is(err, errNoPet) {
+		t.Fatalf("owner left the save behind: %v", err)
+	}
+}
@@ -0,0 +1,117 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Illness is a sickness a BitBuddy catches from sustained neglect.
+type Illness string
+
+const (
+	IllnessNone        Illness = ""
+	IllnessCold        Illness = "Cold"
+	IllnessStomachAche Illness = "Stomach ache"
+	IllnessExhaustion  Illness = "Exhaustion"
+)
+
+// IsSick reports whether the pet currently has an illness.
+func (b *BitBuddy) IsSick() bool {
+	return b.Illness != IllnessNone
+}
+
+// checkHealth runs once per tick after decay. It tracks how long each stat
+// has been in its bad zone, makes the pet ill once one has been bad for too
+// long, and moves Health up or down depending on how the pet is doing.
+func (b *BitBuddy) checkHealth() {
+	rules := b.rules().Health
+	b.StarvingTicks = streak(b.StarvingTicks, b.Hunger >= rules.StarvingHunger)
+	b.GloomyTicks = streak(b.GloomyTicks, b.Happiness <= rules.GloomyHappiness)
+	b.DrainedTicks = streak(b.DrainedTicks, b.Energy <= rules.DrainedEnergy)
+	if b.StarvingTicks == 1 {
+		b.record(EventStat, "starving", fmt.Sprintf("Hunger reached %d", b.Hunger))
+	}
+	if b.GloomyTicks == 1 {
+		b.record(EventStat, "gloomy", fmt.Sprintf("Happiness fell to %d", b.Happiness))
+	}
+	if b.DrainedTicks == 1 {
+		b.record(EventStat, "drained", fmt.Sprintf("Energy fell to %d", b.Energy))
+	}
+
+	if !b.IsSick() {
+		switch {
+		case b.StarvingTicks >= rules.SicknessAfterTicks:
+			b.fallIll(IllnessStomachAche)
+		case b.DrainedTicks >= rules.SicknessAfterTicks:
+			b.fallIll(IllnessExhaustion)
+		case b.GloomyTicks >= rules.SicknessAfterTicks:
+			b.fallIll(IllnessCold)
+		}
+	}
+
+	neglected := b.StarvingTicks > 0 || b.GloomyTicks > 0 || b.DrainedTicks > 0
+	switch {
+	case b.IsSick():
+		b.Health -= rules.SickDamage
+	case neglected:
+		b.Health -= rules.NeglectDamage
+	default:
+		b.Health += rules.Recovery
+	}
+	b.Health = clampStat(b.Health)
+}
+
+// fallIll gives the pet an illness.
+func (b *BitBuddy) fallIll(illness Illness) {
+	b.Illness = illness
+	b.SickSince = b.now()
+	b.record(EventHealth, "fell-ill", "Caught "+strings.ToLower(string(illness)))
+}
+
+// errNotSick is returned by Medicine when there is nothing to cure.
+var errNotSick = errors.New("not sick")
+
+// Medicine uses one medicine from the inventory to cure any illness and
+// restore some Health. Medicine is not wasted on a healthy pet.
+func (b *BitBuddy) Medicine() error {
+	if !b.IsSick() {
+		return errNotSick
+	}
+	if !b.Owns("medicine") {
+		return errors.New("out of medicine - buy some in the Shop")
+	}
+	before := b.snapshot()
+	b.Inventory["medicine"]--
+	cured := b.Illness
+	b.Illness = IllnessNone
+	b.SickSince = time.Time{}
+	b.StarvingTicks, b.GloomyTicks, b.DrainedTicks = 0, 0, 0
+	b.Health = clampStat(b.Health + b.rules().Health.MedicineHealth)
+	b.Happiness = clampStat(b.Happiness - b.rules().Health.MedicineHappiness)
+	b.UpdatedAt = b.now()
+	b.recordAction("medicine", "Gave medicine for "+strings.ToLower(string(cured)), before)
+	b.record(EventHealth, "cured", "Recovered from "+strings.ToLower(string(cured)))
+	return nil
+}
+
+// streak extends a run of consecutive ticks while cond holds and resets it
+// otherwise.
+func streak(n int, cond bool) int {
+	if cond {
+		return n + 1
+	}
+	return 0
+}
+
+// clampStat keeps a stat within minStat..maxStat.
+func clampStat(v int) int {
+	if v < minStat {
+		return minStat
+	}
+	if v > maxStat {
+		return maxStat
+	}
+	return v
+}
@@ -0,0 +1,220 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// EventKind groups history events so the History screen can filter them.
+type EventKind string
+
+const (
+	EventAction EventKind = "action" // care actions, plus the pet waking up on its own
+	EventStat   EventKind = "stat"   // a stat crossed into a bad zone, or changed while away
+	EventStage  EventKind = "stage"  // hatching and growing up
+	EventHealth EventKind = "health" // illness, recovery and death
+	EventWorld  EventKind = "world"  // random world events, see WorldEvent
+)
+
+// eventKinds is the filter order on the History screen; "" means all.
+var eventKinds = []EventKind{"", EventAction, EventStat, EventStage, EventHealth, EventWorld}
+
+// Event is one entry in the pet's append-only care history.
+type Event struct {
+	At      time.Time
+	Kind    EventKind
+	Action  string         // short machine name, e.g. "feed" or "fell-ill"
+	Actor   string         `json:",omitempty"` // who did it; empty when the pet did it on its own
+	Detail  string         // human-readable description
+	Changes map[string]int `json:",omitempty"` // stat deltas caused by the event
+}
+
+// statOrder is the order stat changes are listed in.
+var statOrder = []string{"Hunger", "Happiness", "Energy", "Health", "Hygiene", "Weight"}
+
+// statSnapshot captures the stats before an action so its effect can be logged.
+type statSnapshot map[string]int
+
+func (b *BitBuddy) snapshot() statSnapshot {
+	return statSnapshot{
+		"Hunger":    b.Hunger,
+		"Happiness": b.Happiness,
+		"Energy":    b.Energy,
+		"Health":    b.Health,
+		"Hygiene":   b.Hygiene,
+		"Weight":    b.Weight,
+	}
+}
+
+// changes returns the stats that differ between the snapshot and now.
+func (s statSnapshot) changes(b *BitBuddy) map[string]int {
+	now := b.snapshot()
+	diff := make(map[string]int)
+	for name, before := range s {
+		if d := now[name] - before; d != 0 {
+			diff[name] = d
+		}
+	}
+	if len(diff) == 0 {
+		return nil
+	}
+	return diff
+}
+
+// record appends an event that happened on its own (decay, growing up,
+// falling ill).
+func (b *BitBuddy) record(kind EventKind, action, detail string) {
+	b.recordAt(b.now(), kind, action, detail)
+}
+
+// recordAt is record for something that happened at at rather than now.
+func (b *BitBuddy) recordAt(at time.Time, kind EventKind, action, detail string) {
+	b.History = append(b.History, Event{
+		At:     at,
+		Kind:   kind,
+		Action: action,
+		Detail: detail,
+	})
+}
+
+// recordAction appends something the current player did, with the stat
+// changes it caused since before was taken.
+func (b *BitBuddy) recordAction(action, detail string, before statSnapshot) {
+	b.History = append(b.History, Event{
+		At:      b.now(),
+		Kind:    EventAction,
+		Action:  action,
+		Actor:   b.sim.Actor,
+		Detail:  detail,
+		Changes: before.changes(b),
+	})
+}
+
+// LastEvent returns the most recent event with the given action, if any.
+func (b *BitBuddy) LastEvent(action string) (Event, bool) {
+	for i := len(b.History) - 1; i >= 0; i-- {
+		if b.History[i].Action == action {
+			return b.History[i], true
+		}
+	}
+	return Event{}, false
+}
+
+// FilterHistory returns events of kind, newest first. An empty kind matches
+// every event.
+func (b *BitBuddy) FilterHistory(kind EventKind) []Event {
+	var out []Event
+	for i := len(b.History) - 1; i >= 0; i-- {
+		if kind == "" || b.History[i].Kind == kind {
+			out = append(out, b.History[i])
+		}
+	}
+	return out
+}
+
+// ChangesString lists an event's stat changes, e.g. "Hunger -20, Weight +1".
+func (e Event) ChangesString() string {
+	var parts []string
+	for _, name := range statOrder {
+		if d, ok := e.Changes[name]; ok {
+			parts = append(parts, fmt.Sprintf("%s %+d", name, d))
+		}
+	}
+	return strings.Join(parts, ", ")
+}
+
+// -- HISTORY SCREEN --
+
+// historyPageSize is how many events the History screen shows at once.
+const historyPageSize = 12
+
+// updateHistory handles keys on the History screen: scroll, filter, close.
+func (m model) updateHistory(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	events := m.buddy.FilterHistory(eventKinds[m.historyFilter])
+	maxOffset := len(events) - historyPageSize
+	if maxOffset < 0 {
+		maxOffset = 0
+	}
+	switch msg.String() {
+	case "up", "k":
+		m.historyOffset--
+	case "down", "j":
+		m.historyOffset++
+	case "pgup", "b":
+		m.historyOffset -= historyPageSize
+	case "pgdown", " ":
+		m.historyOffset += historyPageSize
+	case "home", "g":
+		m.historyOffset = 0
+	case "tab", "f":
+		m.historyFilter = (m.historyFilter + 1) % len(eventKinds)
+		m.historyOffset = 0
+		return m, nil
+	case "esc", "l", "q":
+		m.showHistory = false
+		return m, nil
+	}
+	if m.historyOffset > maxOffset {
+		m.historyOffset = maxOffset
+	}
+	if m.historyOffset < 0 {
+		m.historyOffset = 0
+	}
+	return m, nil
+}
+
+// renderHistory lists the filtered history, newest first.
+func (m model) renderHistory() string {
+	var b strings.Builder
+	kind := eventKinds[m.historyFilter]
+	label := "all"
+	if kind != "" {
+		label = string(kind)
+	}
+	events := m.buddy.FilterHistory(kind)
+	b.WriteString(fmt.Sprintf("History - %s (%d events)\n\n", label, len(events)))
+	if len(events) == 0 {
+		b.WriteString(menuChoiceStyle.Render("Nothing yet.") + "\n")
+	}
+	end := m.historyOffset + historyPageSize
+	if end > len(events) {
+		end = len(events)
+	}
+	for _, e := range events[m.historyOffset:end] {
+		who := e.Actor
+		if who == "" {
+			who = "-"
+		}
+		line := fmt.Sprintf("%s  %-8s %s", e.At.Format("01-02 15:04"), truncate(who, 8), e.Detail)
+		if changes := e.ChangesString(); changes != "" {
+			line += " (" + changes + ")"
+		}
+		b.WriteString(line + "\n")
+	}
+	b.WriteString(quitStyle.Render("Up/Down scroll | Tab filter | Esc back"))
+	return b.String()
+}
+
+// lastCareLine answers "who last fed the pet and when?" for the mood panel.
+func (m model) lastCareLine() string {
+	e, ok := m.buddy.LastEvent("feed")
+	if !ok {
+		return "Last fed: never"
+	}
+	ago := formatDuration(m.sim.Now().Sub(e.At))
+	if e.Actor == "" {
+		return "Last fed: " + ago + " ago"
+	}
+	return "Last fed: " + ago + " ago by " + e.Actor
+}
+
+// truncate shortens s to at most n characters.
+func truncate(s string, n int) string {
+	if len(s) <= n {
+		return s
+	}
+	return s[:n]
+}
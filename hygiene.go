@@ -0,0 +1,56 @@
+package main
+
+import (
+	"fmt"
+	"time"
+)
+
+// Messes are dropped on the ground row, to the right of the pet art.
+const (
+	messMinX = 14
+	messMaxX = 22
+)
+
+// Mess is something the pet left behind that needs cleaning up.
+type Mess struct {
+	X  int // column on the sky canvas
+	At time.Time
+}
+
+// digest counts down after a meal and leaves a mess when it is done.
+func (b *BitBuddy) digest() {
+	if b.DigestTicks == 0 {
+		return
+	}
+	b.DigestTicks--
+	if b.DigestTicks > 0 || len(b.Messes) >= b.rules().Hygiene.MaxMesses {
+		return
+	}
+	x := messMinX + b.sim.Rand.Intn(messMaxX-messMinX+1)
+	b.Messes = append(b.Messes, Mess{X: x, At: b.now()})
+}
+
+// checkHygiene runs once per tick: every uncleaned mess drains Hygiene and
+// Happiness, and a filthy pet slowly loses Health.
+func (b *BitBuddy) checkHygiene() {
+	rules := b.rules().Hygiene
+	b.digest()
+	n := len(b.Messes)
+	b.Hygiene = clampStat(b.Hygiene - n*rules.MessHygieneDrain)
+	b.Happiness = clampStat(b.Happiness - n*rules.MessHappinessDrain)
+	if b.Hygiene <= rules.DirtyHygiene {
+		b.Health = clampStat(b.Health - rules.DirtyDamage)
+	}
+}
+
+// Clean removes every mess and restores Hygiene. It returns how many messes
+// were cleaned up.
+func (b *BitBuddy) Clean() int {
+	before := b.snapshot()
+	n := len(b.Messes)
+	b.Messes = nil
+	b.Hygiene = maxStat
+	b.UpdatedAt = b.now()
+	b.recordAction("clean", fmt.Sprintf("Cleaned up %d mess(es)", n), before)
+	return n
+}
@@ -0,0 +1,124 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// errLocked means another bitbuddy has the save open.
+var errLocked = errors.New("another bitbuddy has this save open")
+
+// errSpectating is returned by a spectator's store instead of writing.
+var errSpectating = errors.New("spectating: the save belongs to another bitbuddy")
+
+// Lock is an advisory lock on the save, held by the one bitbuddy allowed to
+// write it. acquireLock and Release are in lock_unix.go and lock_other.go.
+type Lock struct {
+	f    *os.File
+	path string
+}
+
+// lockPath is the lock file for the pet saved under key, beside the save,
+// so different pets in one store can be played at once.
+func lockPath(key string) string {
+	return filepath.Join(filepath.Dir(saveFile), key+".lock")
+}
+
+// writeOwner records who holds the lock, for the message other instances
+// show.
+func (l *Lock) writeOwner() {
+	l.f.Truncate(0)
+	l.f.WriteAt([]byte(fmt.Sprintf("%d %s\n", os.Getpid(), currentUser())), 0)
+}
+
+// lockedBy wraps errLocked with whoever the lock file says holds it.
+func lockedBy(path string) error {
+	data, _ := os.ReadFile(path)
+	fields := strings.Fields(string(data))
+	switch len(fields) {
+	case 0:
+		return errLocked
+	case 1:
+		return fmt.Errorf("%w (process %s)", errLocked, fields[0])
+	}
+	return fmt.Errorf("%w (process %s, user %s)", errLocked, fields[0], fields[1])
+}
+
+// spectatorStore wraps the store of a bitbuddy that couldn't take the lock:
+// it can read the pet but every write fails with errSpectating.
+type spectatorStore struct {
+	Store
+}
+
+func (spectatorStore) Save(string, *BitBuddy) error { return errSpectating }
+func (spectatorStore) Delete(string) error          { return errSpectating }
+func (spectatorStore) Bury(string, Memorial) error  { return errSpectating }
+
+// unwrapStore returns the store a spectator is watching, or s if it isn't
+// a spectator's.
+func unwrapStore(s Store) Store {
+	if spectator, ok := s.(spectatorStore); ok {
+		return spectator.Store
+	}
+	return s
+}
+
+// -- SPECTATOR MODE --
+
+// spectating reports whether this instance is only watching.
+func (m model) spectating() bool {
+	_, ok := m.store.(spectatorStore)
+	return ok
+}
+
+// isSpectatorBlocked reports whether a key would change the pet: picking
+// from the menu, switching species or adopting. Moving around, looking at
+// screens and quitting still work while spectating.
+func isSpectatorBlocked(msg tea.KeyMsg) bool {
+	switch msg.String() {
+	case "enter", "p":
+		return true
+	}
+	return false
+}
+
+// spectatorLine explains spectator mode above the menu.
+func (m model) spectatorLine() string {
+	return sickStyle.Render("Spectating: "+m.spectator) + "\n" +
+		menuChoiceStyle.Render("Nothing you do here is saved. You'll take over when the other one quits.")
+}
+
+// updateSpectator runs instead of the usual tick while spectating. It takes
+// over if the other instance has let go of the save, and otherwise mirrors
+// whatever it last saved. The owner saves every tick, so that is shown as
+// is; only on taking over does the pet catch up on the time since.
+func (m model) updateSpectator() (tea.Model, tea.Cmd) {
+	next := tea.Sequence(tick(m.sim.Rules.Tick.Duration), m.spinner.Tick)
+	if lock, err := acquireLock(lockPath(m.key)); err == nil {
+		m.lock = lock
+		m.store = unwrapStore(m.store)
+		m.spectator = ""
+	}
+	if buddy, err := load(m.store, m.key, m.sim); err == nil {
+		if !m.spectating() {
+			buddy.CatchUp(m.sim.Now())
+		}
+		m.buddy = buddy
+		if !buddy.IsDead() {
+			m.memorial = nil
+		} else if m.memorial == nil {
+			m.enterMemorial()
+		}
+	}
+	if !m.spectating() {
+		m.statusMessage = "The other BitBuddy closed - you're looking after " + m.buddy.Name + " now."
+		return m, tea.Batch(next, clearStatusAfter(4*time.Second))
+	}
+	return m, next
+}
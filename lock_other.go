@@ -0,0 +1,33 @@
+//go:build !unix
+
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// acquireLock creates path exclusively; without flock the file existing is
+// the lock. A crash leaves it behind, so the error says how to clear it.
+func acquireLock(path string) (*Lock, error) {
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return nil, err
+	}
+	f, err := os.OpenFile(path, os.O_RDWR|os.O_CREATE|os.O_EXCL, 0644)
+	if os.IsExist(err) {
+		return nil, fmt.Errorf("%w; if it is no longer running, delete %s", lockedBy(path), path)
+	}
+	if err != nil {
+		return nil, err
+	}
+	l := &Lock{f: f, path: path}
+	l.writeOwner()
+	return l, nil
+}
+
+// Release unlocks by removing the lock file.
+func (l *Lock) Release() error {
+	l.f.Close()
+	return os.Remove(l.path)
+}
@@ -0,0 +1,59 @@
+package main
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+// The owner saves every tick and the spectator shows exactly that, without
+// replaying time of its own, until it takes the save over.
+func TestSpectatorMirrorsOwner(t *testing.T) {
+	old := saveFile
+	saveFile = filepath.Join(t.TempDir(), saveName)
+	t.Cleanup(func() { saveFile = old })
+
+	clock := NewManualClock(time.Date(2026, 3, 1, 9, 0, 0, 0, time.UTC))
+	store := NewMemoryStore()
+	lock, err := acquireLock(lockPath(defaultPetKey))
+	if err != nil {
+		t.Fatal(err)
+	}
+	ownerSim, watchSim := NewSim(clock, 1), NewSim(clock, 2)
+	owner := initialModel(ownerSim, store, defaultPetKey, NewBitBuddy(ownerSim, "Echo"))
+	owner.lock = lock
+	watcher := initialModel(watchSim, spectatorStore{store}, defaultPetKey, NewBitBuddy(watchSim, "Echo"))
+	watcher.spectator = errLocked.Error()
+
+	for i := 0; i < 20; i++ {
+		clock.Advance(ownerSim.Rules.Tick.Duration)
+		next, _ := owner.Update(tickMsg{})
+		owner = next.(model)
+	}
+	next, _ := watcher.Update(tickMsg{})
+	watcher = next.(model)
+	if watcher.buddy.Hunger != owner.buddy.Hunger || !watcher.buddy.UpdatedAt.Equal(owner.buddy.UpdatedAt) {
+		t.Fatalf("spectator shows hunger %d at %v, owner has %d at %v",
+			watcher.buddy.Hunger, watcher.buddy.UpdatedAt, owner.buddy.Hunger, owner.buddy.UpdatedAt)
+	}
+
+	// Time the owner hasn't saved yet isn't the spectator's to replay
+	clock.Advance(48 * time.Hour)
+	next, _ = watcher.Update(tickMsg{})
+	watcher = next.(model)
+	if !watcher.buddy.UpdatedAt.Equal(owner.buddy.UpdatedAt) || watcher.memorial != nil {
+		t.Fatalf("spectator ran the pet on to %v, owner saved it at %v", watcher.buddy.UpdatedAt, owner.buddy.UpdatedAt)
+	}
+
+	// Once the owner lets go, the spectator takes over and catches up
+	lock.Release()
+	next, _ = watcher.Update(tickMsg{})
+	watcher = next.(model)
+	t.Cleanup(func() { watcher.lock.Release() })
+	if watcher.spectating() {
+		t.Fatal("spectator didn't take over the released save")
+	}
+	if !watcher.buddy.UpdatedAt.Equal(clock.Now()) {
+		t.Errorf("pet didn't catch up on taking over: updated %v, now %v", watcher.buddy.UpdatedAt, clock.Now())
+	}
+}
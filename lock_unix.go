@@ -0,0 +1,40 @@
+//go:build unix
+
+package main
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"syscall"
+)
+
+// acquireLock takes an exclusive flock on path without waiting. The kernel
+// drops it when the process exits, however it exits, so a crash never
+// leaves the save locked.
+func acquireLock(path string) (*Lock, error) {
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return nil, err
+	}
+	f, err := os.OpenFile(path, os.O_RDWR|os.O_CREATE, 0644)
+	if err != nil {
+		return nil, err
+	}
+	if err := syscall.Flock(int(f.Fd()), syscall.LOCK_EX|syscall.LOCK_NB); err != nil {
+		f.Close()
+		if errors.Is(err, syscall.EWOULDBLOCK) {
+			return nil, lockedBy(path)
+		}
+		return nil, err
+	}
+	l := &Lock{f: f, path: path}
+	l.writeOwner()
+	return l, nil
+}
+
+// Release unlocks. The file stays: removing it while another process is
+// waiting to open it would let two instances lock different files.
+func (l *Lock) Release() error {
+	defer l.f.Close()
+	return syscall.Flock(int(l.f.Fd()), syscall.LOCK_UN)
+}
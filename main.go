@@ -1,25 +1,223 @@
 package main
 
 import (
+    "errors"
+    "flag"
     "fmt"
-    "math/rand"
     "os"
+    "os/user"
+    "strconv"
+    "strings"
     "time"
 
     tea "github.com/charmbracelet/bubbletea"
 )
 
 func main() {
-    rand.Seed(time.Now().UnixNano())
-    buddy, err := load()
+    seed := flag.Int64("seed", 0, "random seed for a reproducible run (0 picks one from the clock)")
+    hardcore := flag.Bool("hardcore", false, "let long neglect kill the pet (cannot be turned off for a pet)")
+    preset := flag.String("preset", defaultPreset, "game-balance preset: "+strings.Join(presetNames(), ", "))
+    rulesFile := flag.String("rules", "", "game-balance rules file (JSON); overrides --preset")
+    bell := flag.Bool("bell", false, "ring the terminal bell when the pet calls for attention")
+    flag.IntVar(&keepBackups, "backups", keepBackups, "how many save backups to keep, at most one an hour (0 disables backups)")
+    saveFlag := flag.String("save-file", "", "save file path (default $BITBUDDY_HOME/"+saveName+", else under $XDG_DATA_HOME or ~/.local/share)")
+    defaultStore := os.Getenv("BITBUDDY_STORE")
+    if defaultStore == "" {
+        defaultStore = "file"
+    }
+    pet := flag.String("pet", "", "which saved pet to play, for stores holding several (see 'bitbuddy pets')")
+    storeKind := flag.String("store", defaultStore, "where pets are saved: file (a JSON file per pet) or db (one database file); $BITBUDDY_STORE sets the default")
+    flag.Usage = func() {
+        fmt.Fprintf(flag.CommandLine.Output(), "Usage: %s [flags] [pets | restore [backup]]\n\n", os.Args[0])
+        flag.PrintDefaults()
+    }
+    flag.Parse()
+
+    moved, err := resolveSaveFile(*saveFlag, *storeKind)
+    if err != nil {
+        fmt.Println("Error finding save file:", err)
+        os.Exit(1)
+    }
+    store, key, err := openStore(*storeKind, *pet)
+    if err != nil {
+        fmt.Println("Error opening store:", err)
+        os.Exit(1)
+    }
+
+    if flag.Arg(0) == "pets" {
+        if err := runPets(store, key); err != nil {
+            fmt.Println("Error listing pets:", err)
+            os.Exit(1)
+        }
+        return
+    }
+
+    // Only one bitbuddy may write the save; any other just watches
+    lock, lockErr := acquireLock(lockPath(key))
+    if lockErr != nil && !errors.Is(lockErr, errLocked) {
+        fmt.Println("Error locking save:", lockErr)
+        os.Exit(1)
+    }
+
+    if flag.Arg(0) == "restore" {
+        if moved != "" {
+            fmt.Println(moved)
+        }
+        if lockErr != nil {
+            fmt.Println("Error restoring backup:", lockErr, "- quit it first")
+            os.Exit(1)
+        }
+        err := runRestore(store, key, flag.Args()[1:])
+        lock.Release()
+        if err != nil {
+            fmt.Println("Error restoring backup:", err)
+            os.Exit(1)
+        }
+        return
+    }
+    if lockErr != nil {
+        store = spectatorStore{store}
+    }
+
+    if *seed == 0 {
+        *seed = time.Now().UnixNano()
+    }
+    sim := NewSim(nil, *seed)
+    rules, err := loadPreset(*preset)
+    if *rulesFile != "" {
+        rules, err = loadRulesFile(*rulesFile)
+    }
+    if err != nil {
+        fmt.Println("Error loading rules:", err)
+        os.Exit(1)
+    }
+    sim.Rules = rules
+    sim.Actor = currentUser()
+    if dir, err := userSpeciesDir(); err == nil {
+        if err := sim.Species.LoadDir(dir); err != nil {
+            fmt.Println("Error loading species:", err)
+            os.Exit(1)
+        }
+    }
+
+    buddy, err := load(store, key, sim)
     if err != nil {
         fmt.Println("Error loading saved data:", err)
         os.Exit(1)
     }
+    if *hardcore {
+        buddy.Hardcore = true
+    }
+    // A spectator shows the pet as the owner last saved it; catching up is
+    // the owner's job
+    var away *awayReport
+    if lockErr == nil {
+        away = buddy.CatchUp(sim.Now())
+    }
 
-	p := tea.NewProgram(initialModel(buddy))
-	if _, err := p.Run(); err != nil {
+	m := initialModel(sim, store, key, buddy)
+	m.lock = lock
+	if lockErr != nil {
+		m.spectator = lockErr.Error()
+	}
+	m.awayMessage = strings.TrimSpace(moved + "\n" + away.String())
+	m.bell = *bell
+	if greet := buddy.Greeting(); greet != "" {
+		m.awayMessage = strings.TrimSpace(greet + "\n" + m.awayMessage)
+	}
+	p := tea.NewProgram(m)
+	final, err := p.Run()
+	if err != nil {
 		fmt.Println("Error running program:", err)
 		os.Exit(1)
 	}
+	// A spectator may have taken the lock over while running
+	if fm, ok := final.(model); ok && fm.lock != nil {
+		fm.lock.Release()
+	}
+}
+
+// runRestore implements the restore command: with no argument it lists the
+// pet's backups, newest first; with a number from that list or a backup file
+// name it rolls the pet back to that backup. Only the file store keeps
+// backups.
+func runRestore(store Store, key string, args []string) error {
+    files, ok := store.(*FileStore)
+    if !ok {
+        return errors.New("only the file store keeps backups")
+    }
+    backups, err := files.Backups(key)
+    if err != nil {
+        return err
+    }
+    if len(backups) == 0 {
+        fmt.Println("No backups in", files.BackupsPath())
+        return nil
+    }
+    // Newest first, as listed
+    for i, j := 0, len(backups)-1; i < j; i, j = i+1, j-1 {
+        backups[i], backups[j] = backups[j], backups[i]
+    }
+    if len(args) == 0 {
+        fmt.Println("Backups in", files.BackupsPath()+":")
+        for i, name := range backups {
+            fmt.Printf("  %d  %s  %s\n", i+1, name, describePet(files.LoadBackup(name)))
+        }
+        fmt.Println("\nRun 'bitbuddy restore <number>' to roll back to one.")
+        return nil
+    }
+    name := args[0]
+    if n, err := strconv.Atoi(name); err == nil {
+        if n < 1 || n > len(backups) {
+            return fmt.Errorf("no backup number %d", n)
+        }
+        name = backups[n-1]
+    }
+    if err := files.Restore(key, name); err != nil {
+        return err
+    }
+    fmt.Printf("Restored %s from %s (the previous save was backed up).\n", saveFile, name)
+    return nil
+}
+
+// runPets implements the pets command: it lists every pet in the store,
+// marking the one --pet (or the default) would play.
+func runPets(store Store, current string) error {
+    keys, err := store.List()
+    if err != nil {
+        return err
+    }
+    if len(keys) == 0 {
+        fmt.Println("No pets saved in", store)
+        return nil
+    }
+    fmt.Println("Pets in", store.String()+":")
+    for _, key := range keys {
+        mark := " "
+        if key == current {
+            mark = "*"
+        }
+        fmt.Printf(" %s %-12s %s\n", mark, key, describePet(store.Load(key)))
+    }
+    fmt.Println("\nRun 'bitbuddy --pet <name>' to play one, or to start a new one.")
+    return nil
+}
+
+// describePet summarises a saved pet for the pets and restore listings.
+func describePet(b *BitBuddy, err error) string {
+    if errors.Is(err, errSaveTooNew) {
+        return "(from a newer version)"
+    }
+    if err != nil {
+        return "(damaged)"
+    }
+    return fmt.Sprintf("%s the %s, %s", b.Name, b.PetType, b.Stage)
+}
+
+// currentUser names whoever is at the keyboard, for the care history.
+func currentUser() string {
+    if u, err := user.Current(); err == nil && u.Username != "" {
+        return u.Username
+    }
+    return os.Getenv("USER")
 }
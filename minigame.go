@@ -0,0 +1,112 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/spinner"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// Minigame is a game played from the Play action. It is a Bubble Tea model
+// in its own right: while one is running the main model hands it key presses
+// and its own messages, and draws its View in the right-hand panel. Update
+// must return the Minigame itself.
+type Minigame interface {
+	tea.Model
+	// Result reports the score, 0-100, once the game is over. over is false
+	// while it is still being played.
+	Result() (score int, over bool)
+}
+
+// minigameInfo describes a game in the picker.
+type minigameInfo struct {
+	Name        string
+	Description string
+	New         func(sim *Sim) Minigame
+}
+
+// minigames are the games on offer, in picker order.
+var minigames = []minigameInfo{
+	{"guess the number", "Guess a number from 1 to 20 in 5 tries.", newGuessGame},
+	{"reaction", "Press space as soon as you see GO!", newReactionGame},
+}
+
+// updateGamePicker handles keys while choosing a game to play.
+func (m model) updateGamePicker(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "up", "k":
+		if m.gameCursor > 0 {
+			m.gameCursor--
+		}
+	case "down", "j":
+		if m.gameCursor < len(minigames)-1 {
+			m.gameCursor++
+		}
+	case "esc", "q":
+		m.pickingGame = false
+		m.currentAction = ""
+	case "enter":
+		m.pickingGame = false
+		m.gameChoice = minigames[m.gameCursor].Name
+		m.game = minigames[m.gameCursor].New(m.sim)
+		return m, m.game.Init()
+	}
+	return m, nil
+}
+
+// updateGame delegates msg to the running minigame. Esc abandons it with no
+// effect; once it is over its score is played out as a normal Play action.
+func (m model) updateGame(msg tea.Msg) (tea.Model, tea.Cmd) {
+	if key, ok := msg.(tea.KeyMsg); ok {
+		switch key.String() {
+		case "ctrl+c":
+			m.game = nil
+			m.currentAction = ""
+			return m.quit()
+		case "esc":
+			m.game = nil
+			m.currentAction = ""
+			m.statusMessage = "Maybe later."
+			return m, clearStatusAfter(2 * time.Second)
+		}
+	}
+	next, cmd := m.game.Update(msg)
+	m.game = next.(Minigame)
+	if score, over := m.game.Result(); over {
+		m.game = nil
+		m.gameScore = score
+		m2, actionCmd := m.runAction()
+		return m2, tea.Batch(cmd, actionCmd)
+	}
+	return m, cmd
+}
+
+// isMainMsg reports whether msg drives the pet and the canvas rather than
+// the running minigame; those keep flowing while a game is played.
+func isMainMsg(msg tea.Msg) bool {
+	switch msg.(type) {
+	case tickMsg, animTickMsg, spinner.TickMsg, clearStatusMsg, tea.WindowSizeMsg:
+		return true
+	}
+	return false
+}
+
+// renderGamePicker lists the minigames.
+func (m model) renderGamePicker() string {
+	var b strings.Builder
+	b.WriteString("What should " + m.buddy.Name + " play?\n\n")
+	for i, g := range minigames {
+		style := menuChoiceStyle
+		cursor := " "
+		if m.gameCursor == i {
+			style = selectedChoiceStyle
+			cursor = ">"
+		}
+		b.WriteString(style.Render(fmt.Sprintf("%s %s", cursor, g.Name)) + "\n")
+		b.WriteString(menuChoiceStyle.Render("    "+g.Description) + "\n")
+	}
+	b.WriteString("\n" + quitStyle.Render("Enter play | Esc back"))
+	return b.String()
+}
@@ -0,0 +1,407 @@
+package main
+
+import (
+	"bytes"
+	"embed"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"time"
+)
+
+// rulePresets are the game-balance presets that ship with BitBuddy. A team
+// can start from one of these and pass its own copy with --rules.
+//
+//go:embed rules/*.json
+var rulePresets embed.FS
+
+// defaultPreset is used when neither --rules nor --preset is given.
+const defaultPreset = "normal"
+
+// Rules holds every game-balance number. Stat changes read from here rather
+// than from constants so the game can be rebalanced without recompiling.
+type Rules struct {
+	Name      string         `json:"name"`
+	Tick      Duration       `json:"tick"` // how often stats update while open
+	Start     StartRules     `json:"start"`
+	Play      PlayRules      `json:"play"`
+	Train     TrainRules     `json:"train"`
+	Sleep     SleepRules     `json:"sleep"`
+	Feed      FeedRules      `json:"feed"`
+	Stages    []StageRules   `json:"stages"`
+	Growth    GrowthRules    `json:"growth"`
+	Mood      MoodRules      `json:"mood"`
+	Health    HealthRules    `json:"health"`
+	Hygiene   HygieneRules   `json:"hygiene"`
+	Weight    WeightRules    `json:"weight"`
+	CatchUp   CatchUpRules   `json:"catch_up"`
+	Death     DeathRules     `json:"death"`
+	Economy   EconomyRules   `json:"economy"`
+	Bond      BondRules      `json:"bond"`
+	Events    EventRules     `json:"events"`
+	Attention AttentionRules `json:"attention"`
+}
+
+// StartRules are the stats a new pet hatches with.
+type StartRules struct {
+	Hunger    int `json:"hunger"`
+	Happiness int `json:"happiness"`
+	Energy    int `json:"energy"`
+	Weight    int `json:"weight"`
+	Traits    int `json:"traits"` // personality traits rolled, if the trait file has that many
+}
+
+// PlayRules are the effects of one Play.
+type PlayRules struct {
+	Happiness int `json:"happiness"` // gained
+	Energy    int `json:"energy"`    // spent, scaled by the species
+	Weight    int `json:"weight"`    // lost
+}
+
+// TrainRules cover teaching and performing tricks. The chance a training
+// session succeeds is the average of Energy and Happiness, less the trick's
+// difficulty and level_penalty per level already learned, kept within
+// min_chance..max_chance.
+type TrainRules struct {
+	MaxLevel         int `json:"max_level"`
+	Energy           int `json:"energy"`     // spent per session
+	MinEnergy        int `json:"min_energy"` // too tired to train below this
+	LevelPenalty     int `json:"level_penalty"`
+	MinChance        int `json:"min_chance"`
+	MaxChance        int `json:"max_chance"`
+	SuccessHappiness int `json:"success_happiness"`
+	FailHappiness    int `json:"fail_happiness"`
+	PerformEnergy    int `json:"perform_energy"`
+	PerformHappiness int `json:"perform_happiness"` // at max level; scaled down below it
+}
+
+// SleepRules cover sleeping as a timed state.
+type SleepRules struct {
+	EnergyPerTick    int      `json:"energy_per_tick"`    // regenerated while asleep
+	AwakeEnergyDrain int      `json:"awake_energy_drain"` // lost per tick while awake
+	WakeEnergy       int      `json:"wake_energy"`        // wakes naturally at this Energy
+	MaxDuration      Duration `json:"max_duration"`       // wakes naturally after this long
+	GrumpyBelow      int      `json:"grumpy_below"`       // woken below this Energy is grumpy
+	GrumpyHappiness  int      `json:"grumpy_happiness"`   // Happiness lost when grumpy
+	HungerPercent    int      `json:"hunger_percent"`     // Hunger decay while asleep, % of awake
+	HappinessPercent int      `json:"happiness_percent"`  // Happiness decay while asleep, % of awake
+}
+
+// FeedRules cover eating beyond the per-food effects in the food catalog.
+type FeedRules struct {
+	DigestTicks     int `json:"digest_ticks"`      // ticks until a meal becomes a mess
+	TreatLimit      int `json:"treat_limit"`       // treats per day before they hurt
+	TreatOverWeight int `json:"treat_over_weight"` // extra weight per treat past the limit
+	TreatSickChance int `json:"treat_sick_chance"` // percent chance per treat past the limit of a stomach ache
+}
+
+// StageRules describe one life stage: when it begins and its decay per tick.
+type StageRules struct {
+	Stage          LifeStage `json:"stage"`
+	MinAge         Duration  `json:"min_age"` // under average care
+	HungerDecay    int       `json:"hunger_decay"`
+	HappinessDecay int       `json:"happiness_decay"`
+}
+
+// GrowthRules let care speed up or hold back growing up: a pet whose care
+// quality is at least good_care ages towards the next stage at fast_percent
+// of the usual pace, and one below poor_care at slow_percent. Old age comes
+// on its own schedule regardless.
+type GrowthRules struct {
+	GoodCare    int `json:"good_care"`
+	PoorCare    int `json:"poor_care"`
+	FastPercent int `json:"fast_percent"`
+	SlowPercent int `json:"slow_percent"`
+}
+
+// MoodRules are the minimum mood scores (Happiness + Energy - Hunger) for
+// each mood; anything below Tired is Grumpy.
+type MoodRules struct {
+	Ecstatic int `json:"ecstatic"`
+	Happy    int `json:"happy"`
+	Okay     int `json:"okay"`
+	Tired    int `json:"tired"`
+}
+
+// HealthRules cover sickness and Health changes.
+type HealthRules struct {
+	SicknessAfterTicks int `json:"sickness_after_ticks"`
+	StarvingHunger     int `json:"starving_hunger"`
+	GloomyHappiness    int `json:"gloomy_happiness"`
+	DrainedEnergy      int `json:"drained_energy"`
+	SickDecayFactor    int `json:"sick_decay_factor"`
+	SickDamage         int `json:"sick_damage"`    // Health lost per tick while ill
+	NeglectDamage      int `json:"neglect_damage"` // Health lost per tick while a stat is bad
+	Recovery           int `json:"recovery"`       // Health regained per tick otherwise
+	MedicineHealth     int `json:"medicine_health"`
+	MedicineHappiness  int `json:"medicine_happiness"`
+}
+
+// HygieneRules cover messes.
+type HygieneRules struct {
+	MaxMesses          int `json:"max_messes"`
+	MessHygieneDrain   int `json:"mess_hygiene_drain"`
+	MessHappinessDrain int `json:"mess_happiness_drain"`
+	DirtyHygiene       int `json:"dirty_hygiene"`
+	DirtyDamage        int `json:"dirty_damage"`
+}
+
+// WeightRules cover being overweight.
+type WeightRules struct {
+	Heavy       int `json:"heavy"`
+	HeavyDamage int `json:"heavy_damage"`
+}
+
+// CatchUpRules limit the decay replayed for time spent away.
+type CatchUpRules struct {
+	MaxAway  Duration `json:"max_away"`
+	MaxTicks int      `json:"max_ticks"`
+}
+
+// DeathRules cover hardcore mode. When Enabled every pet is hardcore,
+// otherwise only pets started with --hardcore are.
+type DeathRules struct {
+	Enabled      bool `json:"enabled"`
+	AfterTicks   int  `json:"after_ticks"`    // ticks at zero Health before death
+	CatchUpTicks int  `json:"catch_up_ticks"` // replaces catch_up.max_ticks for hardcore pets
+}
+
+// EconomyRules cover coins.
+type EconomyRules struct {
+	StartCoins      int `json:"start_coins"`
+	CareReward      int `json:"care_reward"`       // coins earned for a streak of good care
+	CareRewardTicks int `json:"care_reward_ticks"` // ticks the streak must last
+	GoodCareAbove   int `json:"good_care_above"`   // minimum wellbeing that counts as good care
+	GameCoins       int `json:"game_coins"`        // coins for a perfect minigame score
+}
+
+// BondRules cover the Bond between owner and pet.
+type BondRules struct {
+	Start        int `json:"start"`
+	PerCareDay   int `json:"per_care_day"` // gained for each day with some care
+	GapPenalty   int `json:"gap_penalty"`  // lost for each day without any
+	GreetAbove   int `json:"greet_above"`  // the pet greets you at startup at this Bond
+	RefuseBelow  int `json:"refuse_below"` // below this Bond the pet may refuse to Play
+	RefuseChance int `json:"refuse_chance"`
+	// MistakePenalty is lost for every care mistake, when it happened.
+	MistakePenalty int `json:"mistake_penalty"`
+}
+
+// EventRules cover random world events.
+type EventRules struct {
+	Chance int `json:"chance"` // percent chance per tick that an event fires
+}
+
+// AttentionRules cover attention calls. The pet calls when a stat crosses
+// its threshold (or it falls ill) and a call unanswered for grace is a care
+// mistake.
+type AttentionRules struct {
+	Grace          Duration `json:"grace"`
+	HungerAbove    int      `json:"hunger_above"`
+	HappinessBelow int      `json:"happiness_below"`
+	EnergyBelow    int      `json:"energy_below"`
+	HygieneBelow   int      `json:"hygiene_below"`
+}
+
+// Duration is a time.Duration written as a string such as "5s" or "72h".
+type Duration struct {
+	time.Duration
+}
+
+func (d Duration) MarshalJSON() ([]byte, error) {
+	return json.Marshal(d.String())
+}
+
+func (d *Duration) UnmarshalJSON(data []byte) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return fmt.Errorf("duration must be a string like \"5s\": %w", err)
+	}
+	v, err := time.ParseDuration(s)
+	if err != nil {
+		return err
+	}
+	d.Duration = v
+	return nil
+}
+
+// presetNames lists the built-in presets.
+func presetNames() []string {
+	entries, _ := rulePresets.ReadDir("rules")
+	var names []string
+	for _, e := range entries {
+		names = append(names, strings.TrimSuffix(e.Name(), ".json"))
+	}
+	sort.Strings(names)
+	return names
+}
+
+// loadPreset returns a built-in preset by name.
+func loadPreset(name string) (*Rules, error) {
+	data, err := rulePresets.ReadFile("rules/" + name + ".json")
+	if err != nil {
+		return nil, fmt.Errorf("unknown preset %q (have %s)", name, strings.Join(presetNames(), ", "))
+	}
+	return parseRules(data)
+}
+
+// loadRulesFile reads and validates a rules file.
+func loadRulesFile(path string) (*Rules, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	r, err := parseRules(data)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return r, nil
+}
+
+func parseRules(data []byte) (*Rules, error) {
+	var r Rules
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(&r); err != nil {
+		return nil, err
+	}
+	if err := r.validate(); err != nil {
+		return nil, err
+	}
+	return &r, nil
+}
+
+// validate rejects rules the game cannot run with, reporting every problem
+// at once so a rules file can be fixed in one pass.
+func (r *Rules) validate() error {
+	var errs []error
+	check := func(ok bool, format string, args ...any) {
+		if !ok {
+			errs = append(errs, fmt.Errorf(format, args...))
+		}
+	}
+	stat := func(name string, v int) {
+		check(v >= minStat && v <= maxStat, "%s must be between %d and %d, got %d", name, minStat, maxStat, v)
+	}
+	nonNegative := func(name string, v int) {
+		check(v >= 0, "%s must not be negative, got %d", name, v)
+	}
+
+	check(r.Tick.Duration > 0, "tick must be positive")
+	stat("start.hunger", r.Start.Hunger)
+	stat("start.happiness", r.Start.Happiness)
+	stat("start.energy", r.Start.Energy)
+	check(r.Start.Weight >= minWeight && r.Start.Weight <= maxWeight,
+		"start.weight must be between %d and %d, got %d", minWeight, maxWeight, r.Start.Weight)
+	nonNegative("start.traits", r.Start.Traits)
+	nonNegative("play.happiness", r.Play.Happiness)
+	nonNegative("play.energy", r.Play.Energy)
+	nonNegative("play.weight", r.Play.Weight)
+	check(r.Train.MaxLevel > 0, "train.max_level must be positive")
+	nonNegative("train.energy", r.Train.Energy)
+	stat("train.min_energy", r.Train.MinEnergy)
+	nonNegative("train.level_penalty", r.Train.LevelPenalty)
+	check(r.Train.MinChance >= 0 && r.Train.MinChance <= r.Train.MaxChance && r.Train.MaxChance <= 100,
+		"train chances must go 0 <= min_chance <= max_chance <= 100")
+	nonNegative("train.success_happiness", r.Train.SuccessHappiness)
+	nonNegative("train.fail_happiness", r.Train.FailHappiness)
+	nonNegative("train.perform_energy", r.Train.PerformEnergy)
+	nonNegative("train.perform_happiness", r.Train.PerformHappiness)
+	check(r.Sleep.EnergyPerTick > 0, "sleep.energy_per_tick must be positive")
+	nonNegative("sleep.awake_energy_drain", r.Sleep.AwakeEnergyDrain)
+	check(r.Sleep.WakeEnergy > minStat && r.Sleep.WakeEnergy <= maxStat,
+		"sleep.wake_energy must be between %d and %d, got %d", minStat+1, maxStat, r.Sleep.WakeEnergy)
+	check(r.Sleep.MaxDuration.Duration > 0, "sleep.max_duration must be positive")
+	stat("sleep.grumpy_below", r.Sleep.GrumpyBelow)
+	nonNegative("sleep.grumpy_happiness", r.Sleep.GrumpyHappiness)
+	nonNegative("sleep.hunger_percent", r.Sleep.HungerPercent)
+	nonNegative("sleep.happiness_percent", r.Sleep.HappinessPercent)
+	check(r.Feed.DigestTicks > 0, "feed.digest_ticks must be positive")
+	nonNegative("feed.treat_limit", r.Feed.TreatLimit)
+	nonNegative("feed.treat_over_weight", r.Feed.TreatOverWeight)
+	check(r.Feed.TreatSickChance >= 0 && r.Feed.TreatSickChance <= 100, "feed.treat_sick_chance must be a percentage, got %d", r.Feed.TreatSickChance)
+
+	check(len(r.Stages) == len(lifeStages), "stages must list all %d life stages in order", len(lifeStages))
+	for i, s := range r.Stages {
+		if i < len(lifeStages) {
+			check(s.Stage == lifeStages[i], "stages[%d] must be %s, got %q", i, lifeStages[i], s.Stage)
+		}
+		if i == 0 {
+			check(s.MinAge.Duration == 0, "stages[0].min_age must be 0")
+		} else {
+			check(s.MinAge.Duration > r.Stages[i-1].MinAge.Duration, "stages[%d].min_age must be after the previous stage", i)
+		}
+		nonNegative(fmt.Sprintf("stages[%d].hunger_decay", i), s.HungerDecay)
+		nonNegative(fmt.Sprintf("stages[%d].happiness_decay", i), s.HappinessDecay)
+	}
+
+	stat("growth.good_care", r.Growth.GoodCare)
+	stat("growth.poor_care", r.Growth.PoorCare)
+	check(r.Growth.PoorCare <= r.Growth.GoodCare, "growth.poor_care must not be above growth.good_care")
+	check(r.Growth.FastPercent > 0, "growth.fast_percent must be positive")
+	check(r.Growth.SlowPercent > 0, "growth.slow_percent must be positive")
+
+	check(r.Mood.Ecstatic > r.Mood.Happy && r.Mood.Happy > r.Mood.Okay && r.Mood.Okay > r.Mood.Tired,
+		"mood thresholds must go ecstatic > happy > okay > tired")
+
+	check(r.Health.SicknessAfterTicks > 0, "health.sickness_after_ticks must be positive")
+	stat("health.starving_hunger", r.Health.StarvingHunger)
+	stat("health.gloomy_happiness", r.Health.GloomyHappiness)
+	stat("health.drained_energy", r.Health.DrainedEnergy)
+	check(r.Health.SickDecayFactor >= 1, "health.sick_decay_factor must be at least 1")
+	nonNegative("health.sick_damage", r.Health.SickDamage)
+	nonNegative("health.neglect_damage", r.Health.NeglectDamage)
+	nonNegative("health.recovery", r.Health.Recovery)
+	nonNegative("health.medicine_health", r.Health.MedicineHealth)
+	nonNegative("health.medicine_happiness", r.Health.MedicineHappiness)
+
+	check(r.Hygiene.MaxMesses > 0, "hygiene.max_messes must be positive")
+	nonNegative("hygiene.mess_hygiene_drain", r.Hygiene.MessHygieneDrain)
+	nonNegative("hygiene.mess_happiness_drain", r.Hygiene.MessHappinessDrain)
+	stat("hygiene.dirty_hygiene", r.Hygiene.DirtyHygiene)
+	nonNegative("hygiene.dirty_damage", r.Hygiene.DirtyDamage)
+
+	check(r.Weight.Heavy > minWeight && r.Weight.Heavy <= maxWeight,
+		"weight.heavy must be between %d and %d, got %d", minWeight+1, maxWeight, r.Weight.Heavy)
+	nonNegative("weight.heavy_damage", r.Weight.HeavyDamage)
+
+	check(r.CatchUp.MaxAway.Duration >= 0, "catch_up.max_away must not be negative")
+	nonNegative("catch_up.max_ticks", r.CatchUp.MaxTicks)
+	check(r.Death.AfterTicks > 0, "death.after_ticks must be positive")
+	nonNegative("death.catch_up_ticks", r.Death.CatchUpTicks)
+
+	nonNegative("economy.start_coins", r.Economy.StartCoins)
+	nonNegative("economy.care_reward", r.Economy.CareReward)
+	check(r.Economy.CareRewardTicks > 0, "economy.care_reward_ticks must be positive")
+	stat("economy.good_care_above", r.Economy.GoodCareAbove)
+	nonNegative("economy.game_coins", r.Economy.GameCoins)
+
+	stat("bond.start", r.Bond.Start)
+	nonNegative("bond.per_care_day", r.Bond.PerCareDay)
+	nonNegative("bond.gap_penalty", r.Bond.GapPenalty)
+	nonNegative("bond.mistake_penalty", r.Bond.MistakePenalty)
+	stat("bond.greet_above", r.Bond.GreetAbove)
+	stat("bond.refuse_below", r.Bond.RefuseBelow)
+	check(r.Attention.Grace.Duration > 0, "attention.grace must be positive")
+	stat("attention.hunger_above", r.Attention.HungerAbove)
+	stat("attention.happiness_below", r.Attention.HappinessBelow)
+	stat("attention.energy_below", r.Attention.EnergyBelow)
+	stat("attention.hygiene_below", r.Attention.HygieneBelow)
+	check(r.Events.Chance >= 0 && r.Events.Chance <= 100, "events.chance must be a percentage, got %d", r.Events.Chance)
+	check(r.Bond.RefuseChance >= 0 && r.Bond.RefuseChance <= 100, "bond.refuse_chance must be a percentage, got %d", r.Bond.RefuseChance)
+
+	return errors.Join(errs...)
+}
+
+// stage returns the rules for a life stage, falling back to Adult.
+func (r *Rules) stage(s LifeStage) StageRules {
+	for _, sr := range r.Stages {
+		if sr.Stage == s {
+			return sr
+		}
+	}
+	return r.stage(StageAdult)
+}
@@ -0,0 +1,57 @@
+package main
+
+import (
+	"strings"
+	"testing"
+)
+
+// The balance numbers in the rules are validated like the rest.
+func TestValidateRules(t *testing.T) {
+	tests := []struct {
+		name   string
+		mutate func(r *Rules)
+		want   string
+	}{
+		{"treat chance", func(r *Rules) { r.Feed.TreatSickChance = 101 }, "feed.treat_sick_chance"},
+		{"care bands", func(r *Rules) { r.Growth.PoorCare = r.Growth.GoodCare + 1 }, "growth.poor_care"},
+		{"slow growth", func(r *Rules) { r.Growth.SlowPercent = 0 }, "growth.slow_percent"},
+		{"traits", func(r *Rules) { r.Start.Traits = -1 }, "start.traits"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r, err := loadPreset(defaultPreset)
+			if err != nil {
+				t.Fatal(err)
+			}
+			tt.mutate(r)
+			if err := r.validate(); err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("validate() = %v, want an error about %s", err, tt.want)
+			}
+		})
+	}
+}
+
+// Care quality moves growth by the rules' percentages.
+func TestGrowthPace(t *testing.T) {
+	r, err := loadPreset(defaultPreset)
+	if err != nil {
+		t.Fatal(err)
+	}
+	r.Growth = GrowthRules{GoodCare: 80, PoorCare: 20, FastPercent: 200, SlowPercent: 50}
+	for quality, want := range map[int]float64{80: 2, 79: 1, 20: 1, 19: 0.5} {
+		if got := r.growthPace(quality); got != want {
+			t.Errorf("growthPace(%d) = %v, want %v", quality, got, want)
+		}
+	}
+}
+
+// A pet gets start.traits traits, or every trait there is if that's fewer.
+func TestRollTraits(t *testing.T) {
+	sim := NewSim(nil, 1)
+	for _, n := range []int{0, 1, len(sim.Traits), len(sim.Traits) + 3} {
+		sim.Rules.Start.Traits = n
+		if got := sim.rollTraits(); len(got) != min(n, len(sim.Traits)) {
+			t.Errorf("start.traits %d rolled %v", n, got)
+		}
+	}
+}
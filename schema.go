@@ -0,0 +1,151 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+)
+
+// A migration upgrades a save document from one schema version to the next.
+// Migrations work on the raw JSON object rather than on BitBuddy so they
+// keep working however the struct changes later.
+type migration func(doc map[string]any) error
+
+// migrations[i] upgrades a version i save to version i+1. Add new ones to
+// the end, never edit old ones, and add a fixture for the version being
+// left behind under testdata/saves.
+var migrations = []migration{
+	migrateV0,
+}
+
+// schemaVersion is the save format this build writes.
+var schemaVersion = len(migrations)
+
+// errSaveTooNew is returned for a save written by a newer bitbuddy. Loading
+// it would silently drop whatever the newer version added.
+var errSaveTooNew = errors.New("save is from a newer version of bitbuddy")
+
+// encodeSave stamps the pet with the current schema version and encodes it
+// the way every store writes it.
+func encodeSave(b *BitBuddy) ([]byte, error) {
+	b.SchemaVersion = schemaVersion
+	return json.MarshalIndent(b, "", "  ")
+}
+
+// decodeSave upgrades a save document to the current schema and decodes it.
+// The result still needs attaching to a Sim.
+func decodeSave(data []byte) (*BitBuddy, error) {
+	doc, err := migrateSave(data)
+	if err != nil {
+		return nil, err
+	}
+	upgraded, err := json.Marshal(doc)
+	if err != nil {
+		return nil, err
+	}
+	var buddy BitBuddy
+	if err := json.Unmarshal(upgraded, &buddy); err != nil {
+		return nil, err
+	}
+	return &buddy, nil
+}
+
+// migrateSave runs every migration a save document needs, in order, and
+// returns it at the current schema version.
+func migrateSave(data []byte) (map[string]any, error) {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	var doc map[string]any
+	if err := dec.Decode(&doc); err != nil {
+		return nil, err
+	}
+	if doc == nil {
+		return nil, errors.New("save is empty")
+	}
+	version, err := docVersion(doc)
+	if err != nil {
+		return nil, err
+	}
+	if version > schemaVersion {
+		return nil, fmt.Errorf("%w (schema %d, this build reads up to %d); upgrade bitbuddy to open it",
+			errSaveTooNew, version, schemaVersion)
+	}
+	for v := version; v < schemaVersion; v++ {
+		if err := migrations[v](doc); err != nil {
+			return nil, fmt.Errorf("upgrading save from schema %d: %w", v, err)
+		}
+		doc["SchemaVersion"] = v + 1
+	}
+	return doc, nil
+}
+
+// docVersion reads a document's SchemaVersion. Saves from before versioning
+// have none and are version 0.
+func docVersion(doc map[string]any) (int, error) {
+	raw, ok := doc["SchemaVersion"]
+	if !ok {
+		return 0, nil
+	}
+	n, ok := raw.(json.Number)
+	if !ok {
+		return 0, fmt.Errorf("bad SchemaVersion %v", raw)
+	}
+	v, err := n.Int64()
+	if err != nil || v < 0 {
+		return 0, fmt.Errorf("bad SchemaVersion %v", raw)
+	}
+	return int(v), nil
+}
+
+// setDefault sets doc[key] if the save doesn't have it.
+func setDefault(doc map[string]any, key string, value any) {
+	if _, ok := doc[key]; !ok {
+		doc[key] = value
+	}
+}
+
+// migrateV0 upgrades unversioned saves. They grew field by field, so any of
+// the fields added along the way may be missing; Go's zero value would be
+// wrong for these. Everything else missing is settled by load from the game
+// data.
+func migrateV0(doc map[string]any) error {
+	if petType, _ := doc["PetType"].(string); petType == "" {
+		doc["PetType"] = "Cat"
+	}
+	setDefault(doc, "Health", maxStat)
+	setDefault(doc, "Hygiene", maxStat)
+	// Every pet started at 20 before starting weight moved into the rules
+	setDefault(doc, "Weight", 20)
+	// Care mistakes were counted from History before they were kept in
+	// Mistakes; count them the old way so the pet's evolution, bond and
+	// memorial don't change
+	if _, ok := doc["Mistakes"]; !ok {
+		doc["Mistakes"] = legacyMistakes(doc)
+	}
+	return nil
+}
+
+// legacyMistakeActions are the History events that counted as care
+// mistakes before attention calls: letting a stat into its bad zone, and
+// letting the pet fall ill.
+var legacyMistakeActions = map[string]bool{
+	"starving": true,
+	"gloomy":   true,
+	"drained":  true,
+	"fell-ill": true,
+}
+
+// legacyMistakes counts the care mistakes in a save document's History.
+func legacyMistakes(doc map[string]any) int {
+	history, _ := doc["History"].([]any)
+	n := 0
+	for _, e := range history {
+		if event, ok := e.(map[string]any); ok {
+			if action, _ := event["Action"].(string); legacyMistakeActions[action] {
+				n++
+			}
+		}
+	}
+	return n
+}
@@ -0,0 +1,95 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+var update = flag.Bool("update", false, "rewrite the .golden.json files in testdata/saves")
+
+// Every fixture in testdata/saves migrates to exactly its .golden.json.
+func TestMigrationGoldens(t *testing.T) {
+	fixtures, err := filepath.Glob(filepath.Join("testdata", "saves", "*.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, path := range fixtures {
+		if strings.HasSuffix(path, ".golden.json") {
+			continue
+		}
+		t.Run(filepath.Base(path), func(t *testing.T) {
+			data, err := os.ReadFile(path)
+			if err != nil {
+				t.Fatal(err)
+			}
+			doc, err := migrateSave(data)
+			if err != nil {
+				t.Fatal(err)
+			}
+			got, err := json.MarshalIndent(doc, "", "  ")
+			if err != nil {
+				t.Fatal(err)
+			}
+			got = append(got, '\n')
+			golden := strings.TrimSuffix(path, ".json") + ".golden.json"
+			if *update {
+				if err := os.WriteFile(golden, got, 0644); err != nil {
+					t.Fatal(err)
+				}
+				return
+			}
+			want, err := os.ReadFile(golden)
+			if err != nil {
+				t.Fatal(err, "(run go test -run TestMigrationGoldens -update to create it)")
+			}
+			if !bytes.Equal(got, want) {
+				t.Errorf("migrated save differs from %s:\n%s", golden, got)
+			}
+		})
+	}
+}
+
+// The current format decodes as is.
+func TestDecodeCurrentSave(t *testing.T) {
+	data, err := os.ReadFile(filepath.Join("testdata", "saves", "v1.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	b, err := decodeSave(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if b.SchemaVersion != schemaVersion || b.Name != "Pixel" || b.PetType != "Cat" {
+		t.Errorf("decoded %d %q %q", b.SchemaVersion, b.Name, b.PetType)
+	}
+}
+
+// A save from before Mistakes keeps the care mistakes its History counted.
+func TestDecodeLegacyMistakes(t *testing.T) {
+	data, err := os.ReadFile(filepath.Join("testdata", "saves", "v0-mistakes.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	b, err := decodeSave(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if b.CareMistakes() != 4 {
+		t.Errorf("CareMistakes = %d, want the 4 in its History", b.CareMistakes())
+	}
+}
+
+// A save from a newer bitbuddy is refused rather than loaded lossily.
+func TestSaveTooNew(t *testing.T) {
+	data := []byte(fmt.Sprintf(`{"SchemaVersion": %d, "Name": "Future"}`, schemaVersion+1))
+	if _, err := decodeSave(data); !errors.Is(err, errSaveTooNew) {
+		t.Fatalf("err = %v, want errSaveTooNew", err)
+	}
+}
@@ -0,0 +1,193 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// Transaction is one line in the pet's coin ledger.
+type Transaction struct {
+	At      time.Time
+	Amount  int // positive when earned, negative when spent
+	Balance int // Coins after the transaction
+	Reason  string
+	Actor   string `json:",omitempty"`
+}
+
+// transact is the only place Coins change: every earn and spend goes through
+// it and is written to the ledger. Spending more than the pet has fails and
+// leaves the wallet untouched.
+func (b *BitBuddy) transact(amount int, reason string) error {
+	if b.Coins+amount < 0 {
+		return fmt.Errorf("not enough coins: have %d, need %d", b.Coins, -amount)
+	}
+	b.Coins += amount
+	b.Ledger = append(b.Ledger, Transaction{
+		At:      b.now(),
+		Amount:  amount,
+		Balance: b.Coins,
+		Reason:  reason,
+		Actor:   b.sim.Actor,
+	})
+	return nil
+}
+
+// openWallet gives a pet without a ledger its starting coins.
+func (b *BitBuddy) openWallet() {
+	if b.Ledger != nil {
+		return
+	}
+	b.transact(b.rules().Economy.StartCoins, "starting coins")
+}
+
+// earnForCare runs once per tick: a pet kept in good shape for
+// economy.care_reward_ticks ticks in a row earns economy.care_reward coins.
+func (b *BitBuddy) earnForCare() {
+	rules := b.rules().Economy
+	if b.wellbeing() < rules.GoodCareAbove || b.IsSick() {
+		b.GoodCareTicks = 0
+		return
+	}
+	b.GoodCareTicks++
+	if b.GoodCareTicks >= rules.CareRewardTicks {
+		b.GoodCareTicks = 0
+		b.transact(rules.CareReward, "good care")
+	}
+}
+
+// Owns reports whether the pet has at least one of the item.
+func (b *BitBuddy) Owns(id string) bool {
+	return b.Inventory[id] > 0
+}
+
+// Buy spends coins on one of an item from the catalog and adds it to the
+// inventory. Toys and cosmetics can only be bought once, and a new cosmetic
+// is put on straight away.
+func (b *BitBuddy) Buy(id string) (Item, error) {
+	item, ok := b.sim.Catalog.Get(id)
+	if !ok {
+		return item, fmt.Errorf("unknown item %q", id)
+	}
+	if item.Reusable() && b.Owns(id) {
+		return item, fmt.Errorf("already own the %s", strings.ToLower(item.Name))
+	}
+	if err := b.transact(-item.Price, "bought "+item.Name); err != nil {
+		return item, err
+	}
+	if b.Inventory == nil {
+		b.Inventory = make(map[string]int)
+	}
+	b.Inventory[id]++
+	if item.Kind == KindCosmetic {
+		b.Wearing = id
+	}
+	b.UpdatedAt = b.now()
+	b.recordAction("buy", fmt.Sprintf("Bought %s for %d coins", strings.ToLower(item.Name), item.Price), b.snapshot())
+	return item, nil
+}
+
+// ToggleWear puts on an owned cosmetic, or takes it off if already worn.
+func (b *BitBuddy) ToggleWear(id string) bool {
+	if !b.Owns(id) {
+		return false
+	}
+	if b.Wearing == id {
+		b.Wearing = ""
+	} else {
+		b.Wearing = id
+	}
+	return true
+}
+
+// favoriteToy is the owned toy that adds the most Happiness to Play.
+func (b *BitBuddy) favoriteToy() (Item, bool) {
+	var best Item
+	found := false
+	for _, it := range b.sim.Catalog.Kind(KindToy) {
+		if b.Owns(it.ID) && (!found || it.Happiness > best.Happiness) {
+			best, found = it, true
+		}
+	}
+	return best, found
+}
+
+// -- SHOP SCREEN --
+
+// updateShop handles keys in the Shop: Enter buys, or for an owned
+// cosmetic puts it on or takes it off.
+func (m model) updateShop(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	items := m.sim.Catalog.Items()
+	switch msg.String() {
+	case "up", "k":
+		if m.shopCursor > 0 {
+			m.shopCursor--
+		}
+	case "down", "j":
+		if m.shopCursor < len(items)-1 {
+			m.shopCursor++
+		}
+	case "esc", "q":
+		m.shopping = false
+		m.currentAction = ""
+		m.statusMessage = ""
+	case "enter":
+		item := items[m.shopCursor]
+		if item.Kind == KindCosmetic && m.buddy.Owns(item.ID) {
+			m.buddy.ToggleWear(item.ID)
+			if m.buddy.Wearing == item.ID {
+				m.statusMessage = m.buddy.Name + " puts on the " + strings.ToLower(item.Name) + "."
+			} else {
+				m.statusMessage = m.buddy.Name + " takes off the " + strings.ToLower(item.Name) + "."
+			}
+			m.autosave()
+			return m, clearStatusAfter(2 * time.Second)
+		}
+		if _, err := m.buddy.Buy(item.ID); err != nil {
+			m.statusMessage = "Can't buy: " + err.Error()
+		} else {
+			m.statusMessage = fmt.Sprintf("Bought %s! %d coins left.", item.Name, m.buddy.Coins)
+			m.announceAchievements()
+			m.autosave()
+		}
+		return m, clearStatusAfter(2 * time.Second)
+	}
+	return m, nil
+}
+
+// renderShop lists the catalog with prices and what the pet already owns.
+func (m model) renderShop() string {
+	var b strings.Builder
+	b.WriteString(fmt.Sprintf("Shop - %d coins\n", m.buddy.Coins))
+	var kind ItemKind
+	for i, item := range m.sim.Catalog.Items() {
+		if item.Kind != kind {
+			kind = item.Kind
+			b.WriteString("\n" + strings.ToUpper(string(kind[:1])) + string(kind[1:]) + "\n")
+		}
+		style := menuChoiceStyle
+		cursor := " "
+		if m.shopCursor == i {
+			style = selectedChoiceStyle
+			cursor = ">"
+		}
+		owned := fmt.Sprintf("x%-2d", m.buddy.Inventory[item.ID])
+		if item.Reusable() {
+			owned = "   "
+			if m.buddy.Wearing == item.ID {
+				owned = "on "
+			} else if m.buddy.Owns(item.ID) {
+				owned = "own"
+			}
+		}
+		line := fmt.Sprintf("%s %-10s %4dc %s %s", cursor, item.Name, item.Price, owned, itemEffects(item))
+		b.WriteString(style.Render(line) + "\n")
+	}
+	if m.statusMessage != "" {
+		b.WriteString("\n" + statusMessageStyle.Render(m.statusMessage) + "\n")
+	}
+	b.WriteString("\n" + quitStyle.Render("Enter buy/wear | Esc back"))
+	return b.String()
+}
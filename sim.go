@@ -0,0 +1,88 @@
+package main
+
+import (
+	"math/rand"
+	"time"
+)
+
+// Clock tells the simulation what time it is. Everything that reads the time
+// for game purposes goes through a Clock so runs can use fake time.
+type Clock interface {
+	Now() time.Time
+}
+
+// systemClock is the wall clock used for normal play.
+type systemClock struct{}
+
+func (systemClock) Now() time.Time { return time.Now() }
+
+// ManualClock is a Clock that only moves when told to, for tests and replays.
+type ManualClock struct {
+	t time.Time
+}
+
+// NewManualClock returns a ManualClock starting at start.
+func NewManualClock(start time.Time) *ManualClock {
+	return &ManualClock{t: start}
+}
+
+func (c *ManualClock) Now() time.Time { return c.t }
+
+// Advance moves the clock forward by d.
+func (c *ManualClock) Advance(d time.Duration) { c.t = c.t.Add(d) }
+
+// Set jumps the clock to t.
+func (c *ManualClock) Set(t time.Time) { c.t = t }
+
+// Sim is the simulation context shared by the pet and the TUI: the clock, a
+// seeded random source, the species definitions, the game-balance rules and
+// who is playing. Two runs with the same seed, rules and clock readings
+// produce the same game.
+type Sim struct {
+	Clock   Clock
+	Rand    *rand.Rand
+	Events  *rand.Rand // world event rolls only, see RollWorldEvent
+	FX      *rand.Rand // cosmetic animations only, so redraws can't change a seeded run
+	Seed    int64
+	Species *SpeciesRegistry
+	Rules   *Rules
+	Catalog *Catalog
+	Tricks  []Trick
+	Traits  []Trait
+	Actor   string // recorded against actions in the pet's history
+
+	Achievements []Achievement
+	WorldEvents  []WorldEvent
+}
+
+// NewSim creates a simulation context with the default rules preset. A nil
+// clock means the system clock.
+func NewSim(clock Clock, seed int64) *Sim {
+	if clock == nil {
+		clock = systemClock{}
+	}
+	rules, err := loadPreset(defaultPreset)
+	if err != nil {
+		panic("bitbuddy: embedded rules: " + err.Error())
+	}
+	return &Sim{
+		Clock:   clock,
+		Rand:    rand.New(rand.NewSource(seed)),
+		Events:  rand.New(rand.NewSource(seed + 1)),
+		FX:      rand.New(rand.NewSource(seed + 2)),
+		Seed:    seed,
+		Species: newSpeciesRegistry(),
+		Rules:   rules,
+		Catalog: loadCatalog(),
+		Tricks:  loadTricks(),
+		Traits:  loadTraits(),
+
+		Achievements: loadAchievements(),
+		WorldEvents:  loadWorldEvents(),
+	}
+}
+
+// Now is shorthand for s.Clock.Now().
+func (s *Sim) Now() time.Time {
+	return s.Clock.Now()
+}
@@ -0,0 +1,65 @@
+package main
+
+import "time"
+
+// Sleep puts the pet to bed. Energy then regenerates on every tick until it
+// wakes up on its own or is woken. It reports false if the pet is already
+// asleep or not tired enough to sleep.
+func (b *BitBuddy) Sleep() bool {
+	if b.Asleep || b.Energy >= b.rules().Sleep.WakeEnergy {
+		return false
+	}
+	b.Asleep = true
+	b.SleepStartedAt = b.now()
+	b.UpdatedAt = b.now()
+	b.recordAction("sleep", "Put to bed", b.snapshot())
+	return true
+}
+
+// Wake wakes the pet up early. A pet woken before it has rested enough is
+// grumpy about it and loses Happiness; Wake reports whether that happened.
+func (b *BitBuddy) Wake() bool {
+	if !b.Asleep {
+		return false
+	}
+	before := b.snapshot()
+	rules := b.rules().Sleep
+	grumpy := b.Energy < rules.GrumpyBelow
+	if grumpy {
+		b.Happiness = clampStat(b.Happiness - rules.GrumpyHappiness)
+	}
+	slept := b.SleptFor()
+	b.wakeUp()
+	b.UpdatedAt = b.now()
+	detail := "Woken up after " + formatDuration(slept)
+	if grumpy {
+		detail += " (grumpy)"
+	}
+	b.recordAction("wake", detail, before)
+	return grumpy
+}
+
+// SleptFor is how long the pet has been asleep.
+func (b *BitBuddy) SleptFor() time.Duration {
+	if !b.Asleep {
+		return 0
+	}
+	return b.now().Sub(b.SleepStartedAt)
+}
+
+// rest runs once per tick while asleep: Energy regenerates, and the pet wakes
+// naturally once rested or after sleeping for sleep.max_duration.
+func (b *BitBuddy) rest() {
+	rules := b.rules().Sleep
+	traits := b.traitMultipliers()
+	b.Energy = clampStat(b.Energy + scale(rules.EnergyPerTick, traits.SleepEnergy))
+	if b.Energy >= rules.WakeEnergy || b.SleptFor() >= rules.MaxDuration.Duration {
+		b.record(EventAction, "wake", "Woke up on its own after "+formatDuration(b.SleptFor()))
+		b.wakeUp()
+	}
+}
+
+func (b *BitBuddy) wakeUp() {
+	b.Asleep = false
+	b.SleepStartedAt = time.Time{}
+}
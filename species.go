@@ -0,0 +1,193 @@
+package main
+
+import (
+	"embed"
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"math"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// defaultSpecies holds the species that ship with BitBuddy. More can be
+// dropped into the user species directory as JSON files in the same format.
+//
+//go:embed species/*.json
+var defaultSpecies embed.FS
+
+// defaultPetType is the species new pets start as.
+const defaultPetType = "Cat"
+
+// speciesStates are the animation states every species must provide.
+var speciesStates = []string{"idle", "eat", "play", "sleep"}
+
+// Species is a pet type: its art, how fast its stats move, and what it says.
+type Species struct {
+	Name string `json:"name"`
+	// Frames maps an animation state to its frames; each frame is a list of
+	// lines so the art stays readable in JSON.
+	Frames      map[string][][]string `json:"frames"`
+	Multipliers SpeciesMultipliers    `json:"multipliers"`
+	// Dialogue maps an action ("feed", "play", "sleep", "greet") to lines the pet
+	// picks from when the action finishes.
+	Dialogue map[string][]string `json:"dialogue"`
+	// Forms are the adults the species can grow into, see Form.
+	Forms []Form `json:"forms,omitempty"`
+}
+
+// SpeciesMultipliers scale stat changes for a species. Zero means 1.
+type SpeciesMultipliers struct {
+	Hunger    float64 `json:"hunger"`    // Hunger decay per tick
+	Happiness float64 `json:"happiness"` // Happiness decay per tick
+	Energy    float64 `json:"energy"`    // Energy spent playing
+}
+
+// validate checks a species has a name and art for every state.
+func (s *Species) validate() error {
+	if strings.TrimSpace(s.Name) == "" {
+		return fmt.Errorf("species has no name")
+	}
+	for _, state := range speciesStates {
+		if len(s.Frames[state]) == 0 {
+			return fmt.Errorf("species %s: no %q frames", s.Name, state)
+		}
+	}
+	if err := s.validateForms(); err != nil {
+		return fmt.Errorf("species %s: %w", s.Name, err)
+	}
+	return nil
+}
+
+// Frame returns the n-th frame of state, looping, as a newline-terminated
+// sprite. Unknown states fall back to idle.
+func (s *Species) Frame(state string, n int) string {
+	frames := s.Frames[state]
+	if len(frames) == 0 {
+		frames = s.Frames["idle"]
+	}
+	return strings.Join(frames[n%len(frames)], "\n") + "\n"
+}
+
+// FormFrame is Frame for a pet in the given adult form: the form's own art
+// where it has some, the species' otherwise.
+func (s *Species) FormFrame(formID, state string, n int) string {
+	if f := s.form(formID); f != nil {
+		if frames := f.Frames[state]; len(frames) > 0 {
+			return strings.Join(frames[n%len(frames)], "\n") + "\n"
+		}
+	}
+	return s.Frame(state, n)
+}
+
+// Line picks something for the pet to say after action, or fallback if the
+// species has nothing to say about it.
+func (s *Species) Line(sim *Sim, action, fallback string) string {
+	lines := s.Dialogue[action]
+	if len(lines) == 0 {
+		return fallback
+	}
+	return lines[sim.Rand.Intn(len(lines))]
+}
+
+// scale applies a multiplier to a whole-number stat change.
+func scale(v int, mult float64) int {
+	if mult == 0 {
+		return v
+	}
+	return int(math.Round(float64(v) * mult))
+}
+
+// SpeciesRegistry holds every known species, looked up case-insensitively.
+type SpeciesRegistry struct {
+	byName map[string]*Species
+	order  []string // display names, for cycling in the TUI
+}
+
+// newSpeciesRegistry loads the embedded default species. They are part of
+// the binary, so a failure here is a build problem and panics.
+func newSpeciesRegistry() *SpeciesRegistry {
+	r := &SpeciesRegistry{byName: make(map[string]*Species)}
+	if err := r.loadFS(defaultSpecies, "species"); err != nil {
+		panic("bitbuddy: embedded species: " + err.Error())
+	}
+	return r
+}
+
+// userSpeciesDir is where people can add species without recompiling.
+func userSpeciesDir() (string, error) {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "bitbuddy", "species"), nil
+}
+
+// LoadDir adds every *.json species in dir, replacing built-in species of
+// the same name. A missing directory is not an error.
+func (r *SpeciesRegistry) LoadDir(dir string) error {
+	if _, err := os.Stat(dir); os.IsNotExist(err) {
+		return nil
+	}
+	return r.loadFS(os.DirFS(dir), ".")
+}
+
+func (r *SpeciesRegistry) loadFS(fsys fs.FS, dir string) error {
+	paths, err := fs.Glob(fsys, filepath.ToSlash(filepath.Join(dir, "*.json")))
+	if err != nil {
+		return err
+	}
+	sort.Strings(paths)
+	for _, path := range paths {
+		data, err := fs.ReadFile(fsys, path)
+		if err != nil {
+			return err
+		}
+		var s Species
+		if err := json.Unmarshal(data, &s); err != nil {
+			return fmt.Errorf("%s: %w", path, err)
+		}
+		if err := s.validate(); err != nil {
+			return fmt.Errorf("%s: %w", path, err)
+		}
+		r.add(&s)
+	}
+	return nil
+}
+
+func (r *SpeciesRegistry) add(s *Species) {
+	key := strings.ToLower(s.Name)
+	if _, exists := r.byName[key]; !exists {
+		r.order = append(r.order, s.Name)
+	}
+	r.byName[key] = s
+}
+
+// Get returns the named species, or the default one if the name is unknown
+// (e.g. a species file was removed after the pet was saved).
+func (r *SpeciesRegistry) Get(name string) *Species {
+	if s, ok := r.byName[strings.ToLower(name)]; ok {
+		return s
+	}
+	if s, ok := r.byName[strings.ToLower(defaultPetType)]; ok {
+		return s
+	}
+	return r.byName[strings.ToLower(r.order[0])]
+}
+
+// Names lists the species in registration order.
+func (r *SpeciesRegistry) Names() []string {
+	return r.order
+}
+
+// Next returns the species after name, wrapping around.
+func (r *SpeciesRegistry) Next(name string) string {
+	for i, n := range r.order {
+		if strings.EqualFold(n, name) {
+			return r.order[(i+1)%len(r.order)]
+		}
+	}
+	return r.order[0]
+}
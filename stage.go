@@ -0,0 +1,230 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// LifeStage is where a BitBuddy is in its life, from hatching to old age.
+type LifeStage string
+
+const (
+	StageEgg   LifeStage = "Egg"
+	StageBaby  LifeStage = "Baby"
+	StageChild LifeStage = "Child"
+	StageTeen  LifeStage = "Teen"
+	StageAdult LifeStage = "Adult"
+	StageElder LifeStage = "Elder"
+)
+
+// lifeStages is ordered from youngest to oldest. When each stage begins and
+// how fast stats decay in it come from the rules file.
+var lifeStages = []LifeStage{StageEgg, StageBaby, StageChild, StageTeen, StageAdult, StageElder}
+
+// stageIndex returns the position of s in lifeStages, or -1 if unknown.
+func stageIndex(s LifeStage) int {
+	for i, stage := range lifeStages {
+		if stage == s {
+			return i
+		}
+	}
+	return -1
+}
+
+// growthPace turns care quality (0-100) into how quickly a pet grows up.
+// Well cared-for pets reach adulthood sooner; neglected ones are held back.
+// Old age arrives on its own schedule regardless of care.
+func (r *Rules) growthPace(quality int) float64 {
+	switch {
+	case quality >= r.Growth.GoodCare:
+		return float64(r.Growth.FastPercent) / 100
+	case quality < r.Growth.PoorCare:
+		return float64(r.Growth.SlowPercent) / 100
+	default:
+		return 1.0
+	}
+}
+
+// stageFor returns the life stage for a pet of the given age and care quality.
+func (r *Rules) stageFor(age time.Duration, quality int) LifeStage {
+	grown := time.Duration(float64(age) * r.growthPace(quality))
+	stage := StageEgg
+	for _, sr := range r.Stages {
+		threshold := grown
+		if sr.Stage == StageElder {
+			threshold = age
+		}
+		if threshold >= sr.MinAge.Duration {
+			stage = sr.Stage
+		}
+	}
+	return stage
+}
+
+// Age returns how long the pet has been alive.
+func (b *BitBuddy) Age() time.Duration {
+	return b.now().Sub(b.CreatedAt)
+}
+
+// careQuality is the average wellbeing sampled on every tick, 0-100.
+// Pets with no samples yet count as average.
+func (b *BitBuddy) careQuality() int {
+	if b.CareSamples == 0 {
+		return 50
+	}
+	return b.CareTotal / b.CareSamples
+}
+
+// wellbeing scores how the pet is doing right now, 0-100.
+func (b *BitBuddy) wellbeing() int {
+	return ((maxStat - b.Hunger) + b.Happiness) / 2
+}
+
+// sampleCare records the current wellbeing towards careQuality.
+func (b *BitBuddy) sampleCare() {
+	b.CareTotal += b.wellbeing()
+	b.CareSamples++
+}
+
+// advanceStage moves the pet to a later life stage once it is old enough.
+// Stages never go backwards. It reports the previous stage when it changed.
+func (b *BitBuddy) advanceStage() (LifeStage, bool) {
+	next := b.rules().stageFor(b.Age(), b.careQuality())
+	if stageIndex(next) <= stageIndex(b.Stage) {
+		return b.Stage, false
+	}
+	prev := b.Stage
+	b.Stage = next
+	b.StageSince = b.now()
+	if prev == StageEgg {
+		b.record(EventStage, "hatched", "Hatched into a "+strings.ToLower(string(next)))
+	} else {
+		b.record(EventStage, "grew", fmt.Sprintf("Grew from %s to %s", prev, next))
+	}
+	if stageIndex(next) >= stageIndex(StageAdult) && b.Form == "" {
+		b.evolve()
+	}
+	return prev, true
+}
+
+// -- STAGE ART --
+// Egg, Baby and Child share species-agnostic sprites; from Teen onward the
+// species art is used, with adults standing taller and elders leaning on a cane.
+const (
+	eggIdle1 = "" +
+		"   .--.     \n" +
+		"  /    \\    \n" +
+		"  \\    /    \n" +
+		"   '--'     \n"
+	eggIdle2 = "" +
+		"   .--.     \n" +
+		"  / .  \\    \n" +
+		"  \\    /    \n" +
+		"   '--'     \n"
+	eggWobble = "" +
+		"    .--.    \n" +
+		"   /  ` \\   \n" +
+		"   \\    /   \n" +
+		"    '--'    \n"
+
+	babyIdle1 = "" +
+		"            \n" +
+		"   (o.o)    \n" +
+		"   (   )    \n"
+	babyIdle2 = "" +
+		"            \n" +
+		"   (o_o)    \n" +
+		"   (   )    \n"
+	babyEat = "" +
+		"            \n" +
+		"   (o.o)    \n" +
+		"   ( o )    \n"
+	babyPlay = "" +
+		"     !      \n" +
+		"   (^o^)    \n" +
+		"   (   )    \n"
+	babySleep = "" +
+		"        z   \n" +
+		"   (-.-)    \n" +
+		"   (   )    \n"
+
+	childIdle1 = "" +
+		"   ^   ^    \n" +
+		"  ( o.o )   \n" +
+		"   (   )    \n" +
+		"    ' '     \n"
+	childIdle2 = "" +
+		"   ^   ^    \n" +
+		"  ( o_o )   \n" +
+		"   (   )    \n" +
+		"    ' '     \n"
+	childEat = "" +
+		"   ^   ^    \n" +
+		"  ( o.o )   \n" +
+		"   ( o )    \n" +
+		"    ' '     \n"
+	childPlay = "" +
+		"   ^   ^    \n" +
+		"  ( ^o^ )   \n" +
+		"  \\(   )/   \n" +
+		"    ' '     \n"
+	childSleep = "" +
+		"   ^   ^  z \n" +
+		"  ( -.- )   \n" +
+		"   (   )    \n" +
+		"    ' '     \n"
+
+	adultLegs = "   |   |    \n"
+	elderLegs = "   |   |  j \n"
+)
+
+// stageArt returns the sprite for stage given the species art the pet would
+// otherwise show for the current action and frame.
+func (m model) stageArt(stage LifeStage, speciesArt string) string {
+	action := m.artAction()
+	switch stage {
+	case StageEgg:
+		switch m.frame % 6 {
+		case 0, 1:
+			return eggIdle1
+		case 2:
+			return eggWobble
+		default:
+			return eggIdle2
+		}
+	case StageBaby:
+		switch action {
+		case "Feed":
+			return babyEat
+		case "Play":
+			return babyPlay
+		case "Sleep":
+			return babySleep
+		}
+		if m.frame%3 == 2 {
+			return babyIdle2
+		}
+		return babyIdle1
+	case StageChild:
+		switch action {
+		case "Feed":
+			return childEat
+		case "Play":
+			return childPlay
+		case "Sleep":
+			return childSleep
+		}
+		if m.frame%3 == 2 {
+			return childIdle2
+		}
+		return childIdle1
+	case StageAdult:
+		return speciesArt + adultLegs
+	case StageElder:
+		aged := strings.NewReplacer("o.o", "u.u", "o_o", "u_u", "^.^", "u.u").Replace(speciesArt)
+		return aged + elderLegs
+	default: // Teen
+		return speciesArt
+	}
+}
@@ -1,41 +1,537 @@
 package main
 
 import (
-	"encoding/json"
+	"errors"
+	"fmt"
 	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
 )
 
-const saveFile = "bitbuddy.json"
+// Store persists pets under short keys, e.g. "bitbuddy". The game only
+// talks to its Store; which one it gets is chosen by --store.
+type Store interface {
+	// Load returns the pet saved under key, upgraded to the current schema
+	// but not yet attached to a Sim, or errNoPet.
+	Load(key string) (*BitBuddy, error)
+	// Save stores the pet under key, replacing any pet already there.
+	Save(key string, b *BitBuddy) error
+	// List returns the keys of every saved pet, sorted.
+	List() ([]string, error)
+	// Delete removes the pet saved under key. A missing pet is not an error.
+	Delete(key string) error
+	// Bury archives memorial in the store's graveyard and then removes the
+	// pet saved under key, so a failure never loses the pet.
+	Bury(key string, memorial Memorial) error
+	// Graveyard returns every memorial, in the order they were buried.
+	Graveyard() ([]Memorial, error)
+	// String says where the pets are kept, for the help screen.
+	String() string
+}
+
+// errNoPet is returned by Store.Load when nothing is saved under the key.
+var errNoPet = errors.New("no saved pet")
+
+// storeKinds are the values --store accepts. MemoryStore is for tests and
+// isn't one of them.
+var storeKinds = []string{"file", "db"}
+
+// defaultPetKey is the key the game saves its pet under when the store's
+// location doesn't name one.
+const defaultPetKey = "bitbuddy"
+
+// openStore opens the kind of store named by --store at saveFile and
+// returns it with the key of the pet to play: pet (from --pet) if set, else
+// the save file's name for the file store and defaultPetKey for the db. For
+// the file store saveFile then names that pet's file.
+func openStore(kind, pet string) (Store, string, error) {
+	switch kind {
+	case "file":
+		if filepath.Ext(saveFile) != ".json" {
+			return nil, "", fmt.Errorf("the file store saves to .json files, not %s", saveFile)
+		}
+		key := strings.TrimSuffix(filepath.Base(saveFile), ".json")
+		if pet != "" {
+			key = pet
+		}
+		if err := checkKey(key); err != nil {
+			return nil, "", err
+		}
+		store := &FileStore{Dir: filepath.Dir(saveFile), Keep: keepBackups, Every: backupEvery}
+		saveFile = store.path(key)
+		return store, key, nil
+	case "db":
+		key := defaultPetKey
+		if pet != "" {
+			key = pet
+		}
+		if err := checkKey(key); err != nil {
+			return nil, "", err
+		}
+		return &DBStore{Path: saveFile}, key, nil
+	}
+	return nil, "", fmt.Errorf("unknown store %q (want %s)", kind, strings.Join(storeKinds, " or "))
+}
+
+// checkKey rejects keys that can't safely name a file.
+func checkKey(key string) error {
+	if key == "" || key != filepath.Base(key) || strings.HasPrefix(key, ".") {
+		return fmt.Errorf("bad pet name %q", key)
+	}
+	return nil
+}
+
+// saveName is the save's file name, both in the data directory and in the
+// working directory older versions saved to.
+const saveName = "bitbuddy.json"
+
+// dbName is the database's file name in the data directory.
+const dbName = "bitbuddy.db"
+
+// saveFile is the path of the save, or of the database for the db store.
+// main resolves it with resolveSaveFile before the store is opened; the
+// graveyard and backups live beside it.
+var saveFile = saveName
+
+// dataDir is where the save lives unless --save-file says otherwise:
+// $BITBUDDY_HOME if set, else $XDG_DATA_HOME/bitbuddy, else
+// ~/.local/share/bitbuddy.
+func dataDir() (string, error) {
+	if dir := os.Getenv("BITBUDDY_HOME"); dir != "" {
+		return dir, nil
+	}
+	// The XDG spec says to ignore relative paths
+	if dir := os.Getenv("XDG_DATA_HOME"); filepath.IsAbs(dir) {
+		return filepath.Join(dir, "bitbuddy"), nil
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(home, ".local", "share", "bitbuddy"), nil
+}
+
+// resolveSaveFile sets saveFile for the kind of store. An explicit path
+// (from --save-file) is used as is. Otherwise the save goes in dataDir, and
+// for the file store a save left in the working directory by an older
+// version is moved there, along with its graveyard and backups, if the data
+// directory has no save yet. It returns a note for the player when it moved
+// anything.
+func resolveSaveFile(explicit, kind string) (string, error) {
+	if explicit != "" {
+		saveFile = explicit
+		return "", nil
+	}
+	dir, err := dataDir()
+	if err != nil {
+		return "", err
+	}
+	if kind == "db" {
+		saveFile = filepath.Join(dir, dbName)
+		return "", nil
+	}
+	saveFile = filepath.Join(dir, saveName)
+	return migrateLegacySave(saveName, saveFile)
+}
+
+// migrateLegacySave moves the save at legacy, with the graveyard and
+// backups beside it, to dst. It does nothing if there is no legacy save,
+// if it is already at dst, or if dst exists. Nothing already in dst's
+// directory is overwritten: the graveyards are merged, and a backup whose
+// name is taken stays where it was.
+func migrateLegacySave(legacy, dst string) (string, error) {
+	if _, err := os.Stat(legacy); err != nil {
+		return "", nil
+	}
+	if _, err := os.Stat(dst); err == nil {
+		return "", nil
+	}
+	if from, err := filepath.Abs(legacy); err == nil {
+		if to, err := filepath.Abs(dst); err == nil && from == to {
+			return "", nil
+		}
+	}
+	fromDir, toDir := filepath.Dir(legacy), filepath.Dir(dst)
+	// A pet that died in the data directory left its graveyard behind
+	if err := mergeGraveyards(filepath.Join(fromDir, graveyardFile), filepath.Join(toDir, graveyardFile)); err != nil {
+		return "", err
+	}
+	// The save goes last, so an interrupted move is retried next launch
+	var moves [][2]string
+	backups, err := os.ReadDir(filepath.Join(fromDir, backupDir))
+	if err != nil && !os.IsNotExist(err) {
+		return "", err
+	}
+	for _, e := range backups {
+		if !e.IsDir() {
+			moves = append(moves, [2]string{
+				filepath.Join(fromDir, backupDir, e.Name()),
+				filepath.Join(toDir, backupDir, e.Name()),
+			})
+		}
+	}
+	moves = append(moves, [2]string{legacy, dst})
+	for _, mv := range moves {
+		if err := moveFile(mv[0], mv[1]); err != nil {
+			return "", fmt.Errorf("moving %s to %s: %w", mv[0], mv[1], err)
+		}
+	}
+	os.Remove(filepath.Join(fromDir, backupDir)) // only if now empty
+	return fmt.Sprintf("Moved your save from ./%s to %s", saveName, dst), nil
+}
 
-// save takes a BitBuddy and saves its state to a JSON file.
-func save(buddy *BitBuddy) error {
-	data, err := json.MarshalIndent(buddy, "", "  ")
+// moveFile moves src to dst, copying when they are on different file
+// systems. A missing src is not an error, and an existing dst is left alone
+// along with src.
+func moveFile(src, dst string) error {
+	data, err := os.ReadFile(src)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if _, err := os.Stat(dst); err == nil {
+		return nil
+	}
 	if err != nil {
 		return err
 	}
-	return os.WriteFile(saveFile, data, 0644)
+	if err := os.MkdirAll(filepath.Dir(dst), 0755); err != nil {
+		return err
+	}
+	if err := os.Rename(src, dst); err == nil {
+		return nil
+	}
+	if err := writeFileAtomic(dst, data, 0644); err != nil {
+		return err
+	}
+	return os.Remove(src)
 }
 
-// load reads the state from the JSON file and returns a BitBuddy.
-// If the file doesn't exist, it creates a new BitBuddy.
-func load() (*BitBuddy, error) {
-    data, err := os.ReadFile(saveFile)
-    if err != nil {
-        if os.IsNotExist(err) {
-            // If file doesn't exist, create a new BitBuddy
-            return NewBitBuddy("BitBuddy"), nil
-        }
-        return nil, err
-    }
+// FileStore keeps each pet in its own JSON file, <key>.json, in Dir. A save
+// rotates the previous one into the backups first and is written
+// atomically, so a crash mid-save can't lose the pet.
+type FileStore struct {
+	Dir   string
+	Keep  int           // backups kept per pet; 0 disables them
+	Every time.Duration // least time between backups; 0 backs up every save
+	Clock Clock         // stamps backups; nil is the system clock
+
+	lastBackup map[string]time.Time // newest backup per key, read from disk once
+}
+
+// Backups are kept in backupDir, beside the saves, named <key>-<time>.json
+// so they sort oldest first.
+const (
+	backupDir        = "bitbuddy-backups"
+	backupTimeFormat = "20060102-150405.000"
+)
+
+// keepBackups is how many backups the file store keeps; set by --backups.
+var keepBackups = 5
+
+// backupEvery spaces the file store's backups out. The game saves every
+// tick, and backing up each of those would leave only the last few seconds
+// to restore.
+const backupEvery = time.Hour
+
+func (s *FileStore) path(key string) string {
+	return filepath.Join(s.Dir, key+".json")
+}
+
+// now is the time backups are stamped with. It comes from the store rather
+// than the pet, so pets that were never attached to a Sim save too.
+func (s *FileStore) now() time.Time {
+	if s.Clock == nil {
+		return systemClock{}.Now()
+	}
+	return s.Clock.Now()
+}
+
+func (s *FileStore) String() string {
+	return s.Dir
+}
+
+// Load reads the pet's file.
+func (s *FileStore) Load(key string) (*BitBuddy, error) {
+	if err := checkKey(key); err != nil {
+		return nil, err
+	}
+	data, err := os.ReadFile(s.path(key))
+	if os.IsNotExist(err) {
+		return nil, errNoPet
+	}
+	if err != nil {
+		return nil, err
+	}
+	b, err := decodeSave(data)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", s.path(key), err)
+	}
+	return b, nil
+}
+
+// Save backs up the pet's file and replaces it.
+func (s *FileStore) Save(key string, b *BitBuddy) error {
+	if err := checkKey(key); err != nil {
+		return err
+	}
+	data, err := encodeSave(b)
+	if err != nil {
+		return err
+	}
+	now := s.now()
+	if due, err := s.backupDue(key, now); err != nil {
+		return fmt.Errorf("backing up save: %w", err)
+	} else if due {
+		if err := s.backup(key, now); err != nil {
+			return fmt.Errorf("backing up save: %w", err)
+		}
+	}
+	return writeFileAtomic(s.path(key), data, 0644)
+}
+
+// List returns the keys of the JSON files in Dir, leaving out the graveyard.
+func (s *FileStore) List() ([]string, error) {
+	entries, err := os.ReadDir(s.Dir)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var keys []string
+	for _, e := range entries {
+		name := e.Name()
+		if e.IsDir() || name == graveyardFile || filepath.Ext(name) != ".json" {
+			continue
+		}
+		if key := strings.TrimSuffix(name, ".json"); checkKey(key) == nil {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+	return keys, nil
+}
 
-    var buddy BitBuddy
-    err = json.Unmarshal(data, &buddy)
+// Delete removes the pet's file. Its backups are kept.
+func (s *FileStore) Delete(key string) error {
+	if err := checkKey(key); err != nil {
+		return err
+	}
+	if err := os.Remove(s.path(key)); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	return nil
+}
+
+// Bury appends to graveyardFile in Dir.
+func (s *FileStore) Bury(key string, memorial Memorial) error {
+	if err := checkKey(key); err != nil {
+		return err
+	}
+	graves, err := s.Graveyard()
+	if err != nil {
+		return err
+	}
+	if err := writeGraveyard(filepath.Join(s.Dir, graveyardFile), append(graves, memorial)); err != nil {
+		return err
+	}
+	return s.Delete(key)
+}
+
+func (s *FileStore) Graveyard() ([]Memorial, error) {
+	return readGraveyard(filepath.Join(s.Dir, graveyardFile))
+}
+
+// writeFileAtomic replaces path with data: it writes a temporary file in the
+// same directory, fsyncs it and renames it over path, so readers only ever
+// see the old or the new contents. The directory is created if needed.
+func writeFileAtomic(path string, data []byte, perm os.FileMode) error {
+	dir := filepath.Dir(path)
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return err
+	}
+	tmp, err := os.CreateTemp(dir, "."+filepath.Base(path)+"-*.tmp")
+	if err != nil {
+		return err
+	}
+	// Clean up on any failure; after a successful rename this is a no-op
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Sync(); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	if err := os.Chmod(tmp.Name(), perm); err != nil {
+		return err
+	}
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		return err
+	}
+	// Make the rename itself durable; not every platform can sync a directory
+	if d, err := os.Open(dir); err == nil {
+		d.Sync()
+		d.Close()
+	}
+	return nil
+}
+
+// BackupsPath is the directory holding the backups.
+func (s *FileStore) BackupsPath() string {
+	return filepath.Join(s.Dir, backupDir)
+}
+
+// backup copies the pet's current file, if there is one, into the backups
+// as stamp and prunes all but the newest Keep.
+func (s *FileStore) backup(key string, t time.Time) error {
+	if s.Keep <= 0 {
+		return nil
+	}
+	stamp := t.Format(backupTimeFormat)
+	data, err := os.ReadFile(s.path(key))
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	name := filepath.Join(s.BackupsPath(), key+"-"+stamp+".json")
+	if err := writeFileAtomic(name, data, 0644); err != nil {
+		return err
+	}
+	s.setLastBackup(key, t)
+	backups, err := s.Backups(key)
+	if err != nil {
+		return err
+	}
+	for len(backups) > s.Keep {
+		if err := os.Remove(filepath.Join(s.BackupsPath(), backups[0])); err != nil {
+			return err
+		}
+		backups = backups[1:]
+	}
+	return nil
+}
+
+// backupDue reports whether a save at t should back up the one it
+// replaces: always, unless the newest backup is less than Every old. The
+// backups are only listed the first time; after that the store remembers.
+func (s *FileStore) backupDue(key string, t time.Time) (bool, error) {
+	if s.Every <= 0 {
+		return true, nil
+	}
+	last, ok := s.lastBackup[key]
+	if !ok {
+		backups, err := s.Backups(key)
+		if err != nil {
+			return false, err
+		}
+		if n := len(backups); n > 0 {
+			stamp := strings.TrimSuffix(strings.TrimPrefix(backups[n-1], key+"-"), ".json")
+			// Backups only lists names that parse
+			last, _ = time.ParseInLocation(backupTimeFormat, stamp, t.Location())
+		}
+		s.setLastBackup(key, last)
+	}
+	return t.Sub(last) >= s.Every, nil
+}
+
+func (s *FileStore) setLastBackup(key string, t time.Time) {
+	if s.lastBackup == nil {
+		s.lastBackup = make(map[string]time.Time)
+	}
+	s.lastBackup[key] = t
+}
+
+// Backups returns the file names of the pet's backups, oldest first.
+func (s *FileStore) Backups(key string) ([]string, error) {
+	entries, err := os.ReadDir(s.BackupsPath())
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var names []string
+	for _, e := range entries {
+		stamp, ok := strings.CutPrefix(e.Name(), key+"-")
+		if !ok || e.IsDir() {
+			continue
+		}
+		stamp, ok = strings.CutSuffix(stamp, ".json")
+		if _, err := time.Parse(backupTimeFormat, stamp); ok && err == nil {
+			names = append(names, e.Name())
+		}
+	}
+	sort.Strings(names)
+	return names, nil
+}
+
+// LoadBackup reads the named backup.
+func (s *FileStore) LoadBackup(name string) (*BitBuddy, error) {
+	if name != filepath.Base(name) {
+		return nil, fmt.Errorf("not a backup name: %s", name)
+	}
+	data, err := os.ReadFile(filepath.Join(s.BackupsPath(), name))
+	if err != nil {
+		return nil, err
+	}
+	return decodeSave(data)
+}
+
+// Restore rolls the pet back to the named backup. The save being replaced
+// is backed up first, so a restore can itself be undone.
+func (s *FileStore) Restore(key, name string) error {
+	if _, err := s.LoadBackup(name); errors.Is(err, errSaveTooNew) {
+		return fmt.Errorf("backup %s: %w", name, err)
+	} else if err != nil {
+		return fmt.Errorf("backup %s is damaged: %w", name, err)
+	}
+	// Restore the backup byte for byte; Load upgrades it as usual
+	data, err := os.ReadFile(filepath.Join(s.BackupsPath(), name))
+	if err != nil {
+		return err
+	}
+	if err := s.backup(key, s.now()); err != nil {
+		return err
+	}
+	return writeFileAtomic(s.path(key), data, 0644)
+}
+
+// load returns the pet saved under key, attached to sim. If there is none,
+// it creates a new BitBuddy.
+func load(store Store, key string, sim *Sim) (*BitBuddy, error) {
+    buddy, err := store.Load(key)
+    if errors.Is(err, errNoPet) {
+        return NewBitBuddy(sim, "BitBuddy"), nil
+    }
     if err != nil {
         return nil, err
     }
-    // Backward compatibility: default pet type if missing
-    if buddy.PetType == "" {
-        buddy.PetType = "Cat"
+    // The migrations upgraded older saves; what they can't know without
+    // the game data is settled here
+    buddy.attach(sim)
+    // Pets from before personalities get theirs now
+    if buddy.Traits == nil {
+        buddy.Traits = sim.rollTraits()
+    }
+    buddy.restock()
+    buddy.openWallet()
+    // Saves from before life stages start at whatever stage their age implies
+    if buddy.Stage == "" {
+        buddy.Stage = sim.Rules.stageFor(buddy.Age(), buddy.careQuality())
+        buddy.StageSince = sim.Now()
+    }
+    // Adults from before branching evolution settle their form now
+    if stageIndex(buddy.Stage) >= stageIndex(StageAdult) && buddy.Form == "" {
+        buddy.evolve()
     }
-    return &buddy, nil
+    return buddy, nil
 }
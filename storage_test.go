@@ -0,0 +1,105 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+	"time"
+)
+
+// Moving an old ./bitbuddy.json never overwrites what is already in the
+// data directory: graveyards are merged and taken backup names are skipped.
+func TestMigrateLegacySaveKeepsExisting(t *testing.T) {
+	from, to := t.TempDir(), t.TempDir()
+	write := func(path, data string) {
+		t.Helper()
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(data), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	write(filepath.Join(from, saveName), `{"Name":"Old"}`)
+	write(filepath.Join(from, graveyardFile), `[{"Name":"Older","DiedAt":"2025-01-01T00:00:00Z"}]`)
+	write(filepath.Join(from, backupDir, "bitbuddy-20250101-000000.000.json"), "legacy")
+	write(filepath.Join(to, graveyardFile), `[{"Name":"Newer","DiedAt":"2026-01-01T00:00:00Z"}]`)
+	write(filepath.Join(to, backupDir, "bitbuddy-20250101-000000.000.json"), "kept")
+
+	dst := filepath.Join(to, saveName)
+	if _, err := migrateLegacySave(filepath.Join(from, saveName), dst); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := os.Stat(dst); err != nil {
+		t.Fatal("save not moved:", err)
+	}
+	graves, err := readGraveyard(filepath.Join(to, graveyardFile))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(graves) != 2 || graves[0].Name != "Older" || graves[1].Name != "Newer" {
+		t.Errorf("graveyard = %+v, want Older then Newer", graves)
+	}
+	if !graves[0].DiedAt.Equal(time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("DiedAt = %v", graves[0].DiedAt)
+	}
+	kept, _ := os.ReadFile(filepath.Join(to, backupDir, "bitbuddy-20250101-000000.000.json"))
+	if string(kept) != "kept" {
+		t.Errorf("existing backup overwritten with %q", kept)
+	}
+	if _, err := os.Stat(filepath.Join(from, backupDir, "bitbuddy-20250101-000000.000.json")); err != nil {
+		t.Error("clashing legacy backup was dropped")
+	}
+}
+
+// Saving every tick backs up at most once per Every, so the backups still
+// reach back further than the last few ticks.
+func TestBackupsSpacedOut(t *testing.T) {
+	clock := NewManualClock(time.Date(2026, 3, 1, 9, 0, 0, 0, time.UTC))
+	sim := NewSim(clock, 1)
+	s := &FileStore{Dir: t.TempDir(), Keep: 3, Every: time.Hour, Clock: clock}
+	b := NewBitBuddy(sim, "Tock")
+	for i := 0; i < 4*720; i++ { // four hours of 5s ticks
+		if err := s.Save(defaultPetKey, b); err != nil {
+			t.Fatal(err)
+		}
+		clock.Advance(5 * time.Second)
+	}
+	backups, err := s.Backups(defaultPetKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []string{
+		"bitbuddy-20260301-100005.000.json",
+		"bitbuddy-20260301-110005.000.json",
+		"bitbuddy-20260301-120005.000.json",
+	}
+	if !slices.Equal(backups, want) {
+		t.Errorf("backups = %v, want %v", backups, want)
+	}
+
+	// The store remembers its last backup rather than listing them per save
+	if err := os.RemoveAll(s.BackupsPath()); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.Save(defaultPetKey, b); err != nil {
+		t.Fatal(err)
+	}
+	if backups, _ := s.Backups(defaultPetKey); len(backups) != 0 {
+		t.Errorf("backed up again within the hour: %v", backups)
+	}
+
+	// A new store starts from the newest backup on disk
+	fresh := &FileStore{Dir: s.Dir, Keep: 3, Every: time.Hour, Clock: clock}
+	clock.Set(time.Date(2026, 3, 1, 13, 30, 0, 0, time.UTC))
+	if err := s.backup(defaultPetKey, clock.Now().Add(-30*time.Minute)); err != nil {
+		t.Fatal(err)
+	}
+	if err := fresh.Save(defaultPetKey, b); err != nil {
+		t.Fatal(err)
+	}
+	if backups, _ := fresh.Backups(defaultPetKey); len(backups) != 1 {
+		t.Errorf("new store backed up 30 minutes after the last backup: %v", backups)
+	}
+}
@@ -0,0 +1,165 @@
+package main
+
+import (
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	bolt "go.etcd.io/bbolt"
+)
+
+// DBStore keeps every pet in one bbolt database file, keyed by name, for
+// setups with more than one pet. Writes are transactional, so it needs no
+// backups to survive a crash. The file is opened for each operation rather
+// than held open while the game runs.
+type DBStore struct {
+	Path string
+}
+
+// petsBucket holds one encoded save per pet key; graveyardBucket holds the
+// memorials, keyed by a sequence number so they list in burial order.
+var (
+	petsBucket      = []byte("pets")
+	graveyardBucket = []byte("graveyard")
+)
+
+func (s *DBStore) String() string {
+	return s.Path
+}
+
+// view runs fn in a read-only transaction on the bucket named by bucket. A
+// missing database or bucket is empty, so fn isn't called.
+func (s *DBStore) view(bucket []byte, fn func(b *bolt.Bucket) error) error {
+	if _, err := os.Stat(s.Path); os.IsNotExist(err) {
+		return nil
+	}
+	db, err := bolt.Open(s.Path, 0644, &bolt.Options{Timeout: time.Second, ReadOnly: true})
+	if err != nil {
+		return fmt.Errorf("opening %s: %w", s.Path, err)
+	}
+	defer db.Close()
+	return db.View(func(tx *bolt.Tx) error {
+		b := tx.Bucket(bucket)
+		if b == nil {
+			return nil
+		}
+		return fn(b)
+	})
+}
+
+// update runs fn in a read-write transaction, creating the database and
+// buckets if needed.
+func (s *DBStore) update(fn func(tx *bolt.Tx) error) error {
+	if err := os.MkdirAll(filepath.Dir(s.Path), 0755); err != nil {
+		return err
+	}
+	db, err := bolt.Open(s.Path, 0644, &bolt.Options{Timeout: time.Second})
+	if err != nil {
+		return fmt.Errorf("opening %s: %w", s.Path, err)
+	}
+	defer db.Close()
+	return db.Update(func(tx *bolt.Tx) error {
+		for _, name := range [][]byte{petsBucket, graveyardBucket} {
+			if _, err := tx.CreateBucketIfNotExists(name); err != nil {
+				return err
+			}
+		}
+		return fn(tx)
+	})
+}
+
+func (s *DBStore) Load(key string) (*BitBuddy, error) {
+	var data []byte
+	err := s.view(petsBucket, func(pets *bolt.Bucket) error {
+		// Values are only valid inside the transaction
+		if v := pets.Get([]byte(key)); v != nil {
+			data = append([]byte(nil), v...)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	if data == nil {
+		return nil, errNoPet
+	}
+	b, err := decodeSave(data)
+	if err != nil {
+		return nil, fmt.Errorf("%s: pet %s: %w", s.Path, key, err)
+	}
+	return b, nil
+}
+
+func (s *DBStore) Save(key string, b *BitBuddy) error {
+	if err := checkKey(key); err != nil {
+		return err
+	}
+	data, err := encodeSave(b)
+	if err != nil {
+		return err
+	}
+	return s.update(func(tx *bolt.Tx) error {
+		return tx.Bucket(petsBucket).Put([]byte(key), data)
+	})
+}
+
+func (s *DBStore) List() ([]string, error) {
+	var keys []string
+	err := s.view(petsBucket, func(pets *bolt.Bucket) error {
+		return pets.ForEach(func(k, _ []byte) error {
+			keys = append(keys, string(k))
+			return nil
+		})
+	})
+	// bbolt iterates in key order, so keys are already sorted
+	return keys, err
+}
+
+func (s *DBStore) Delete(key string) error {
+	if _, err := os.Stat(s.Path); os.IsNotExist(err) {
+		return nil
+	}
+	return s.update(func(tx *bolt.Tx) error {
+		return tx.Bucket(petsBucket).Delete([]byte(key))
+	})
+}
+
+// Bury archives the memorial and removes the pet in one transaction.
+func (s *DBStore) Bury(key string, memorial Memorial) error {
+	if err := checkKey(key); err != nil {
+		return err
+	}
+	data, err := json.Marshal(memorial)
+	if err != nil {
+		return err
+	}
+	return s.update(func(tx *bolt.Tx) error {
+		graves := tx.Bucket(graveyardBucket)
+		seq, err := graves.NextSequence()
+		if err != nil {
+			return err
+		}
+		if err := graves.Put(binary.BigEndian.AppendUint64(nil, seq), data); err != nil {
+			return err
+		}
+		return tx.Bucket(petsBucket).Delete([]byte(key))
+	})
+}
+
+func (s *DBStore) Graveyard() ([]Memorial, error) {
+	var graves []Memorial
+	err := s.view(graveyardBucket, func(b *bolt.Bucket) error {
+		return b.ForEach(func(_, v []byte) error {
+			var memorial Memorial
+			if err := json.Unmarshal(v, &memorial); err != nil {
+				return err
+			}
+			graves = append(graves, memorial)
+			return nil
+		})
+	})
+	return graves, err
+}
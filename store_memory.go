@@ -0,0 +1,75 @@
+package main
+
+import (
+	"encoding/json"
+	"sort"
+)
+
+// MemoryStore keeps pets in memory, for tests. Pets are held encoded, so
+// Load returns a fresh copy that went through decodeSave like a file would.
+type MemoryStore struct {
+	pets   map[string][]byte
+	graves [][]byte
+}
+
+// NewMemoryStore returns an empty MemoryStore.
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{pets: make(map[string][]byte)}
+}
+
+func (s *MemoryStore) String() string {
+	return "memory"
+}
+
+func (s *MemoryStore) Load(key string) (*BitBuddy, error) {
+	data, ok := s.pets[key]
+	if !ok {
+		return nil, errNoPet
+	}
+	return decodeSave(data)
+}
+
+func (s *MemoryStore) Save(key string, b *BitBuddy) error {
+	if err := checkKey(key); err != nil {
+		return err
+	}
+	data, err := encodeSave(b)
+	if err != nil {
+		return err
+	}
+	s.pets[key] = data
+	return nil
+}
+
+func (s *MemoryStore) List() ([]string, error) {
+	keys := make([]string, 0, len(s.pets))
+	for key := range s.pets {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys, nil
+}
+
+func (s *MemoryStore) Delete(key string) error {
+	delete(s.pets, key)
+	return nil
+}
+
+func (s *MemoryStore) Bury(key string, memorial Memorial) error {
+	data, err := json.Marshal(memorial)
+	if err != nil {
+		return err
+	}
+	s.graves = append(s.graves, data)
+	return s.Delete(key)
+}
+
+func (s *MemoryStore) Graveyard() ([]Memorial, error) {
+	graves := make([]Memorial, len(s.graves))
+	for i, data := range s.graves {
+		if err := json.Unmarshal(data, &graves[i]); err != nil {
+			return nil, err
+		}
+	}
+	return graves, nil
+}
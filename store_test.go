@@ -0,0 +1,92 @@
+package main
+
+import (
+	"errors"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+)
+
+// Every Store keeps the same contract.
+func TestStores(t *testing.T) {
+	stores := map[string]func(dir string) Store{
+		"file":   func(dir string) Store { return &FileStore{Dir: dir, Keep: 2} },
+		"memory": func(string) Store { return NewMemoryStore() },
+		"db":     func(dir string) Store { return &DBStore{Path: filepath.Join(dir, "pets", dbName)} },
+	}
+	for name, open := range stores {
+		t.Run(name, func(t *testing.T) {
+			s := open(t.TempDir())
+			sim := NewSim(NewManualClock(time.Date(2026, 3, 1, 9, 0, 0, 0, time.UTC)), 1)
+
+			if _, err := s.Load("rex"); !errors.Is(err, errNoPet) {
+				t.Fatalf("Load of a missing pet: err = %v, want errNoPet", err)
+			}
+			if keys, err := s.List(); err != nil || len(keys) != 0 {
+				t.Fatalf("List of an empty store = %v, %v", keys, err)
+			}
+			if err := s.Delete("rex"); err != nil {
+				t.Fatalf("Delete of a missing pet: %v", err)
+			}
+
+			rex, ada := NewBitBuddy(sim, "Rex"), NewBitBuddy(sim, "Ada")
+			for key, b := range map[string]*BitBuddy{"rex": rex, "ada": ada} {
+				if err := s.Save(key, b); err != nil {
+					t.Fatal(err)
+				}
+			}
+			rex.Hunger = 77
+			if err := s.Save("rex", rex); err != nil {
+				t.Fatal(err)
+			}
+			got, err := s.Load("rex")
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got.Name != "Rex" || got.Hunger != 77 || got.SchemaVersion != schemaVersion {
+				t.Errorf("Load = %q hunger %d schema %d", got.Name, got.Hunger, got.SchemaVersion)
+			}
+			if got == rex {
+				t.Error("Load returned the saved pointer, not a copy")
+			}
+			// What Load returns isn't attached to a Sim and must save as is
+			if err := s.Save("rex", got); err != nil {
+				t.Fatalf("Save of a loaded pet: %v", err)
+			}
+			if keys, _ := s.List(); !reflect.DeepEqual(keys, []string{"ada", "rex"}) {
+				t.Errorf("List = %v", keys)
+			}
+
+			if err := s.Delete("rex"); err != nil {
+				t.Fatal(err)
+			}
+			if _, err := s.Load("rex"); !errors.Is(err, errNoPet) {
+				t.Errorf("Load after Delete: err = %v", err)
+			}
+			if keys, _ := s.List(); !reflect.DeepEqual(keys, []string{"ada"}) {
+				t.Errorf("List after Delete = %v", keys)
+			}
+			ada.DiedAt, ada.CauseOfDeath = sim.Now(), "Old age"
+			if err := s.Bury("ada", memorialFor(ada)); err != nil {
+				t.Fatal(err)
+			}
+			if _, err := s.Load("ada"); !errors.Is(err, errNoPet) {
+				t.Errorf("Load after Bury: err = %v", err)
+			}
+			if keys, _ := s.List(); len(keys) != 0 {
+				t.Errorf("List after Bury = %v", keys)
+			}
+			if err := s.Bury("rex", memorialFor(rex)); err != nil {
+				t.Fatalf("Bury of a pet no longer saved: %v", err)
+			}
+			graves, err := s.Graveyard()
+			if err != nil || len(graves) != 2 || graves[0].Name != "Ada" || graves[0].Cause != "Old age" || graves[1].Name != "Rex" {
+				t.Errorf("Graveyard = %v, %v", graves, err)
+			}
+			if err := s.Save("../escape", ada); err == nil {
+				t.Error("saved under a key that isn't a plain name")
+			}
+		})
+	}
+}
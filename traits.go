@@ -0,0 +1,166 @@
+package main
+
+import (
+	"embed"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// defaultTraits holds the personality traits a pet can be born with.
+//
+//go:embed traits/traits.json
+var defaultTraits embed.FS
+
+// Trait is a personality trait. It scales stat changes, biases which idle
+// animation the pet shows and gives it its own lines.
+type Trait struct {
+	ID          string           `json:"id"`
+	Name        string           `json:"name"`
+	Description string           `json:"description"`
+	Multipliers TraitMultipliers `json:"multipliers"`
+	// Idle weights the species animation states shown while idle, e.g.
+	// {"idle": 2, "sleep": 1} naps a third of the time.
+	Idle map[string]int `json:"idle"`
+	// Dialogue maps an action to lines used instead of the species' own.
+	Dialogue map[string][]string `json:"dialogue"`
+}
+
+// TraitMultipliers scale stat changes. Zero means 1.
+type TraitMultipliers struct {
+	HungerDecay    float64 `json:"hunger_decay"`    // Hunger gained per tick
+	HappinessDecay float64 `json:"happiness_decay"` // Happiness lost per tick
+	EnergyDrain    float64 `json:"energy_drain"`    // Energy lost per tick awake
+	FoodHunger     float64 `json:"food_hunger"`     // Hunger relieved by food
+	FoodHappiness  float64 `json:"food_happiness"`  // Happiness from food
+	PlayHappiness  float64 `json:"play_happiness"`  // Happiness from Play
+	PlayEnergy     float64 `json:"play_energy"`     // Energy spent playing
+	SleepEnergy    float64 `json:"sleep_energy"`    // Energy regained per tick asleep
+}
+
+// loadTraits parses the embedded traits.
+func loadTraits() []Trait {
+	return mustParse(defaultTraits, "traits/traits.json", parseTraits)
+}
+
+// parseTraits decodes and validates the list of traits.
+func parseTraits(data []byte) ([]Trait, error) {
+	var traits []Trait
+	if err := json.Unmarshal(data, &traits); err != nil {
+		return nil, err
+	}
+	var errs []error
+	for _, t := range traits {
+		if t.ID == "" || t.Name == "" {
+			errs = append(errs, fmt.Errorf("trait %q: id and name are required", t.ID))
+		}
+		for state := range t.Idle {
+			if !contains(speciesStates, state) {
+				errs = append(errs, fmt.Errorf("trait %q: unknown idle state %q", t.ID, state))
+			}
+		}
+	}
+	if len(traits) == 0 {
+		errs = append(errs, errors.New("need at least one trait"))
+	}
+	return traits, errors.Join(errs...)
+}
+
+// rollTraits picks start.traits different traits for a new pet, or every
+// trait if there aren't that many.
+func (s *Sim) rollTraits() []string {
+	n := min(s.Rules.Start.Traits, len(s.Traits))
+	ids := make([]string, 0, n)
+	for _, i := range s.Rand.Perm(len(s.Traits))[:n] {
+		ids = append(ids, s.Traits[i].ID)
+	}
+	return ids
+}
+
+// traits returns the pet's traits. IDs no longer in the trait file are
+// skipped.
+func (b *BitBuddy) traits() []Trait {
+	var out []Trait
+	for _, id := range b.Traits {
+		for _, t := range b.sim.Traits {
+			if t.ID == id {
+				out = append(out, t)
+			}
+		}
+	}
+	return out
+}
+
+// traitMultipliers combines the multipliers of all the pet's traits; every
+// field is the product across traits, so it is never zero.
+func (b *BitBuddy) traitMultipliers() TraitMultipliers {
+	m := TraitMultipliers{1, 1, 1, 1, 1, 1, 1, 1}
+	mul := func(acc *float64, v float64) {
+		if v != 0 {
+			*acc *= v
+		}
+	}
+	for _, t := range b.traits() {
+		tm := t.Multipliers
+		mul(&m.HungerDecay, tm.HungerDecay)
+		mul(&m.HappinessDecay, tm.HappinessDecay)
+		mul(&m.EnergyDrain, tm.EnergyDrain)
+		mul(&m.FoodHunger, tm.FoodHunger)
+		mul(&m.FoodHappiness, tm.FoodHappiness)
+		mul(&m.PlayHappiness, tm.PlayHappiness)
+		mul(&m.PlayEnergy, tm.PlayEnergy)
+		mul(&m.SleepEnergy, tm.SleepEnergy)
+	}
+	return m
+}
+
+// Personality lists the pet's trait names, e.g. "Lazy, Glutton".
+func (b *BitBuddy) Personality() string {
+	var names []string
+	for _, t := range b.traits() {
+		names = append(names, t.Name)
+	}
+	return strings.Join(names, ", ")
+}
+
+// Line picks something for the pet to say after an action: one of its
+// traits' lines if it has any, else its species' lines, else fallback.
+func (b *BitBuddy) Line(action, fallback string) string {
+	var lines []string
+	for _, t := range b.traits() {
+		lines = append(lines, t.Dialogue[action]...)
+	}
+	if len(lines) == 0 {
+		return b.species().Line(b.sim, action, fallback)
+	}
+	return lines[b.sim.Rand.Intn(len(lines))]
+}
+
+// idleState is the species animation state to show while the pet is idle.
+// It changes every idleSpell animation frames, weighted by the pet's
+// traits; the choice is a pure function of the frame so View stays
+// deterministic.
+func (b *BitBuddy) idleState(frame int) string {
+	weights := map[string]int{"idle": 1}
+	for _, t := range b.traits() {
+		for state, w := range t.Idle {
+			weights[state] += w
+		}
+	}
+	total := 0
+	for _, state := range speciesStates {
+		total += weights[state]
+	}
+	pick := int(uint32(frame/idleSpell)*2654435761%(1<<31)) % total
+	for _, state := range speciesStates {
+		if pick < weights[state] {
+			return state
+		}
+		pick -= weights[state]
+	}
+	return "idle"
+}
+
+// idleSpell is how many animation frames an idle state lasts, about 3s.
+const idleSpell = 25
@@ -0,0 +1,224 @@
+package main
+
+import (
+	"embed"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// defaultTricks holds the tricks a pet can be taught and their animations.
+//
+//go:embed tricks/tricks.json
+var defaultTricks embed.FS
+
+// Trick is something a pet can learn in training and perform on request.
+type Trick struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+	// Difficulty lowers the chance of a training session succeeding, in
+	// percentage points.
+	Difficulty int       `json:"difficulty"`
+	MinStage   LifeStage `json:"min_stage"` // the pet must be at least this old
+	// Frames is the animation played while the trick is performed; each
+	// frame is a list of lines, like species frames.
+	Frames [][]string `json:"frames"`
+}
+
+// loadTricks parses the embedded tricks.
+func loadTricks() []Trick {
+	return mustParse(defaultTricks, "tricks/tricks.json", parseTricks)
+}
+
+// parseTricks decodes and validates a list of tricks.
+func parseTricks(data []byte) ([]Trick, error) {
+	var tricks []Trick
+	if err := json.Unmarshal(data, &tricks); err != nil {
+		return nil, err
+	}
+	var errs []error
+	for _, t := range tricks {
+		if t.ID == "" || t.Name == "" || len(t.Frames) == 0 {
+			errs = append(errs, fmt.Errorf("trick %q: id, name and frames are required", t.ID))
+		}
+		if stageIndex(t.MinStage) < 0 {
+			errs = append(errs, fmt.Errorf("trick %q: unknown stage %q", t.ID, t.MinStage))
+		}
+	}
+	return tricks, errors.Join(errs...)
+}
+
+// Frame returns the n-th frame of the trick's animation, looping.
+func (t Trick) Frame(n int) string {
+	return strings.Join(t.Frames[n%len(t.Frames)], "\n") + "\n"
+}
+
+// findTrick looks a trick up by ID.
+func (s *Sim) findTrick(id string) (Trick, bool) {
+	for _, t := range s.Tricks {
+		if t.ID == id {
+			return t, true
+		}
+	}
+	return Trick{}, false
+}
+
+// Skill is the pet's level at a trick; 0 means not learned yet.
+func (b *BitBuddy) Skill(id string) int {
+	return b.Skills[id]
+}
+
+// TrainChance is the percentage chance that a training session for the
+// trick succeeds. Rested, happy pets learn best; harder tricks and higher
+// levels are harder.
+func (b *BitBuddy) TrainChance(t Trick) int {
+	rules := b.rules().Train
+	chance := (b.Energy+b.Happiness)/2 - t.Difficulty - b.Skill(t.ID)*rules.LevelPenalty
+	return max(rules.MinChance, min(rules.MaxChance, chance))
+}
+
+// Train runs one training session for a trick. It always costs Energy; on
+// success the skill goes up a level and the pet is pleased with itself, on
+// failure it gets a little frustrated. It reports whether it succeeded.
+func (b *BitBuddy) Train(id string) (bool, error) {
+	t, ok := b.sim.findTrick(id)
+	if !ok {
+		return false, fmt.Errorf("unknown trick %q", id)
+	}
+	rules := b.rules().Train
+	switch {
+	case stageIndex(b.Stage) < stageIndex(t.MinStage):
+		return false, fmt.Errorf("too young to learn %s", strings.ToLower(t.Name))
+	case b.Skill(id) >= rules.MaxLevel:
+		return false, fmt.Errorf("%s is already mastered", strings.ToLower(t.Name))
+	case b.Energy < rules.MinEnergy:
+		return false, errors.New("too tired to train")
+	}
+	before := b.snapshot()
+	success := b.sim.Rand.Intn(100) < b.TrainChance(t)
+	b.Energy = clampStat(b.Energy - rules.Energy)
+	if success {
+		if b.Skills == nil {
+			b.Skills = make(map[string]int)
+		}
+		b.Skills[id]++
+		b.Happiness = clampStat(b.Happiness + rules.SuccessHappiness)
+		b.UpdatedAt = b.now()
+		b.recordAction("train", fmt.Sprintf("Taught %s (level %d)", strings.ToLower(t.Name), b.Skills[id]), before)
+		return true, nil
+	}
+	b.Happiness = clampStat(b.Happiness - rules.FailHappiness)
+	b.UpdatedAt = b.now()
+	b.recordAction("train-failed", "Training "+strings.ToLower(t.Name)+" didn't stick", before)
+	return false, nil
+}
+
+// PerformTrick has the pet show off a learned trick. Better-practised
+// tricks make it happier.
+func (b *BitBuddy) PerformTrick(id string) error {
+	t, ok := b.sim.findTrick(id)
+	if !ok {
+		return fmt.Errorf("unknown trick %q", id)
+	}
+	level := b.Skill(id)
+	if level == 0 {
+		return fmt.Errorf("hasn't learned %s yet", strings.ToLower(t.Name))
+	}
+	rules := b.rules().Train
+	before := b.snapshot()
+	b.Energy = clampStat(b.Energy - rules.PerformEnergy)
+	b.Happiness = clampStat(b.Happiness + rules.PerformHappiness*level/rules.MaxLevel)
+	b.UpdatedAt = b.now()
+	b.recordAction("trick", "Performed "+strings.ToLower(t.Name), before)
+	return nil
+}
+
+// -- TRICK PICKER --
+
+// updateTrickPicker handles keys while choosing a trick, either to train
+// (currentAction "Train") or to perform (currentAction "Tricks").
+func (m model) updateTrickPicker(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	tricks := m.pickableTricks()
+	switch msg.String() {
+	case "up", "k":
+		if m.trickCursor > 0 {
+			m.trickCursor--
+		}
+	case "down", "j":
+		if m.trickCursor < len(tricks)-1 {
+			m.trickCursor++
+		}
+	case "esc", "q":
+		m.pickingTrick = false
+		m.currentAction = ""
+	case "enter":
+		if len(tricks) == 0 {
+			return m, nil
+		}
+		m.pickingTrick = false
+		m.trickChoice = tricks[m.trickCursor].ID
+		if m.currentAction == "Tricks" {
+			m.currentAction = "Trick"
+		}
+		return m.runAction()
+	}
+	return m, nil
+}
+
+// pickableTricks is every trick when training, and only learned ones when
+// performing.
+func (m model) pickableTricks() []Trick {
+	if m.currentAction == "Train" {
+		return m.sim.Tricks
+	}
+	var learned []Trick
+	for _, t := range m.sim.Tricks {
+		if m.buddy.Skill(t.ID) > 0 {
+			learned = append(learned, t)
+		}
+	}
+	return learned
+}
+
+// renderTrickPicker lists tricks with their skill level and, when training,
+// the chance of success.
+func (m model) renderTrickPicker() string {
+	var b strings.Builder
+	tricks := m.pickableTricks()
+	maxLevel := m.sim.Rules.Train.MaxLevel
+	if m.currentAction == "Train" {
+		b.WriteString("What should " + m.buddy.Name + " learn?\n\n")
+	} else {
+		b.WriteString("Which trick should " + m.buddy.Name + " do?\n\n")
+	}
+	if len(tricks) == 0 {
+		b.WriteString(menuChoiceStyle.Render("No tricks learned yet - try Train.") + "\n")
+	}
+	for i, t := range tricks {
+		style := menuChoiceStyle
+		cursor := " "
+		if m.trickCursor == i {
+			style = selectedChoiceStyle
+			cursor = ">"
+		}
+		level := m.buddy.Skill(t.ID)
+		stars := strings.Repeat("*", level) + strings.Repeat(".", max(0, maxLevel-level))
+		line := fmt.Sprintf("%s %-10s %s", cursor, t.Name, stars)
+		if m.currentAction == "Train" {
+			switch {
+			case stageIndex(m.buddy.Stage) < stageIndex(t.MinStage):
+				line += "  (" + string(t.MinStage) + "+)"
+			case level >= maxLevel:
+				line += "  mastered"
+			default:
+				line += fmt.Sprintf("  %d%%", m.buddy.TrainChance(t))
+			}
+		}
+		b.WriteString(style.Render(line) + "\n")
+	}
+	b.WriteString("\n" + quitStyle.Render("Enter choose | Esc back"))
+	return b.String()
+}
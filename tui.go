@@ -1,8 +1,8 @@
 package main
 
 import (
+	"errors"
 	"fmt"
-	"math/rand"
 	"strings"
 	"time"
 
@@ -34,146 +34,30 @@ var (
 	selectedChoiceStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("#38BDF8")).Bold(true)
 	// Quitting
 	quitStyle = lipgloss.NewStyle().MarginTop(1).Foreground(lipgloss.Color("240"))
-)
-
-// -- ASCII ART FRAMES (ASCII-only for stability) --
-const (
-    // Cat frames
-    catIdle1 = "" +
-        "  /\\_/\\    \n" +
-        " ( o.o )    \n" +
-        "  > ^ <     \n"
-    catIdle2 = "" +
-        "  /\\_/\\    \n" +
-        " ( o_o )    \n" +
-        "  > ^ <     \n"
-    catIdle3 = "" +
-        "  /\\_/\\    \n" +
-        " ( -_- )    \n" +
-        "  > ^ <     \n"
-
-    catEat1 = "" +
-        "  /\\_/\\    \n" +
-        " ( o.o )    \n" +
-        "  > w <     \n"
-    catEat2 = "" +
-        "  /\\_/\\    \n" +
-        " ( o.o )    \n" +
-        "  > o <     \n"
-
-    catPlay1 = "" +
-        "  /\\_/\\    \n" +
-        " ( ^.^ )    \n" +
-        "  > ^ <     \n"
-    catPlay2 = "" +
-        "  /\\_/\\    \n" +
-        " ( ^o^ )    \n" +
-        "  > ^ <     \n"
-
-    catSleep1 = "" +
-        "  /\\_/\\    \n" +
-        " ( -.- ) z  \n" +
-        "  > ^ <     \n"
-    catSleep2 = "" +
-        "  /\\_/\\    \n" +
-        " ( -.- ) zz \n" +
-        "  > ^ <     \n"
-
-    // Corgi frames (distinct muzzle and tiny legs)
-    dogIdle1 = "" +
-        "  /\\_/\\    \n" +
-        " ( o.o )>   \n" +
-        "  |_ _|     \n"
-    dogIdle2 = "" +
-        "  /\\_/\\    \n" +
-        " ( o_o )>   \n" +
-        "  |_ _|     \n"
-    dogIdle3 = "" +
-        "  /\\_/\\    \n" +
-        " ( -_- )>   \n" +
-        "  |_ _|     \n"
-
-    dogEat1 = "" +
-        "  /\\_/\\    \n" +
-        " ( o.o )>   \n" +
-        "  |\\_/|     \n"
-    dogEat2 = "" +
-        "  /\\_/\\    \n" +
-        " ( o.o )>   \n" +
-        "  | o |     \n"
-
-    dogPlay1 = "" +
-        "  /\\_/\\    \n" +
-        " ( ^.^ )>   \n" +
-        "  |_ _|     \n"
-    dogPlay2 = "" +
-        "  /\\_/\\    \n" +
-        " ( ^o^ )>   \n" +
-        "  |_ _|     \n"
-
-    dogSleep1 = "" +
-        "  /\\_/\\    \n" +
-        " ( -.- )> z \n" +
-        "  |_ _|     \n"
-    dogSleep2 = "" +
-        "  /\\_/\\    \n" +
-        " ( -.- )>zz \n" +
-        "  |_ _|     \n"
-
-    // Bunny frames (tall ears)
-    bunIdle1 = "" +
-        "  (\\_/ )    \n" +
-        "  ( o.o)     \n" +
-        "  / > <\\    \n"
-    bunIdle2 = "" +
-        "  (\\_/ )    \n" +
-        "  ( o_o)     \n" +
-        "  / > <\\    \n"
-    bunIdle3 = "" +
-        "  (\\_/ )    \n" +
-        "  ( -_-)     \n" +
-        "  / > <\\    \n"
-
-    bunEat1 = "" +
-        "  (\\_/ )    \n" +
-        "  ( o.o)     \n" +
-        "  / w w\\    \n"
-    bunEat2 = "" +
-        "  (\\_/ )    \n" +
-        "  ( o.o)     \n" +
-        "  / o o\\    \n"
-
-    bunPlay1 = "" +
-        "  (\\_/ )    \n" +
-        "  ( ^.^)     \n" +
-        "  / > <\\    \n"
-    bunPlay2 = "" +
-        "  (\\_/ )    \n" +
-        "  ( ^o^)     \n" +
-        "  / > <\\    \n"
-
-    bunSleep1 = "" +
-        "  (\\_/ )    \n" +
-        "  ( -.-) z   \n" +
-        "  / > <\\    \n"
-    bunSleep2 = "" +
-        "  (\\_/ )    \n" +
-        "  ( -.-) zz  \n" +
-        "  / > <\\    \n"
+	// Sickness status line
+	sickStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("#EF4444")).Bold(true) // Red
 )
 
 // -- MESSAGES --
 type actionMsg struct{ message string }
+type actionDueMsg struct{}
 type clearStatusMsg struct{}
 type tickMsg struct{}
 type animTickMsg struct{}
 
 // -- MODEL --
 type model struct {
+	sim           *Sim
+	store         Store  // where buddy is saved
+	key           string // buddy's key in store
+	lock          *Lock  // held while this instance may write the save
+	spectator     string // why this instance is only watching; see spectatorStore
+	quitUnsaved   bool   // the last quit couldn't save; quitting again exits anyway
 	buddy         *BitBuddy
 	spinner       spinner.Model
 	loading       bool
 	statusMessage string
+	awayMessage   string // "While you were away" summary, cleared on first key
 	currentAction string // To know which art to display
 	cursor        int
 	choices       []string
@@ -187,6 +71,11 @@ type model struct {
     // Action overlays
     confetti []confettiParticle
     zzzs     []zzzParticle
+    bubbles  []bubbleParticle
+
+    // Stage transition animation
+    evolveFrames int       // frames left in the transition
+    evolveFrom   LifeStage // stage shown alternately with the new one
 
     // UI toggles
     showHelp bool
@@ -196,6 +85,46 @@ type model struct {
     // Rename flow
     renaming  bool
     nameInput string
+
+    // Food picker
+    pickingFood bool
+    foodCursor  int
+    foodChoice  string // ID of the food being eaten
+
+    bell bool // ring the terminal bell on new attention calls
+
+    // World event overlay
+    eventFX      []eventParticle
+    eventOverlay EventOverlay
+    eventFrames  int
+
+    // Minigames, played from the Play action
+    pickingGame bool
+    gameCursor  int
+    gameChoice  string   // name of the game being played
+    game        Minigame // non-nil while a game is running
+    gameScore   int      // score of the last finished game
+
+    // Tricks, for training (currentAction "Train") or performing ("Tricks")
+    pickingTrick bool
+    trickCursor  int
+    trickChoice  string // ID of the trick being trained or performed
+
+    // Shop
+    shopping   bool
+    shopCursor int
+
+    // Achievements screen
+    showAchievements bool
+
+    // History screen
+    showHistory   bool
+    historyFilter int // index into eventKinds
+    historyOffset int
+
+    // Death and adopting a new pet
+    memorial *Memorial // set once the pet has died and been buried
+    adopting bool      // the rename flow is naming a brand-new pet
 }
 
 type star struct {
@@ -217,50 +146,110 @@ type zzzParticle struct {
     text string // "z", "zz", "zzz"
 }
 
-func initialModel(buddy *BitBuddy) model {
+type bubbleParticle struct {
+    x, y int
+    life int
+    ch   string // "o", "O", "."
+}
+
+func initialModel(sim *Sim, store Store, key string, buddy *BitBuddy) model {
     s := spinner.New()
     s.Spinner = spinner.Points
     s.Style = lipgloss.NewStyle().Foreground(lipgloss.Color("#00BFFF"))
-    hour := time.Now().Hour()
+    hour := sim.Now().Hour()
     isDay := hour >= 7 && hour < 19
     m := model{
+        sim:     sim,
+        store:   store,
+        key:     key,
         buddy:   buddy,
         spinner: s,
-        choices: []string{"Feed", "Play", "Sleep", "Rename"},
+        choices: []string{"Feed", "Play", "Train", "Tricks", "Sleep", "Clean", "Medicine", "Shop", "Rename"},
         dark:    true,
         day:     isDay,
     }
     setTheme(m.dark)
+    if buddy.IsDead() {
+        m.enterMemorial()
+    }
     return m
 }
 
+// save writes the pet to the store.
+func (m model) save() error {
+	return m.store.Save(m.key, m.buddy)
+}
+
+// autosave saves after every tick and action, so a spectator always mirrors
+// the pet as it is. A failed save is shown but the game goes on; quitting
+// tries again.
+func (m *model) autosave() {
+	if m.spectating() {
+		return
+	}
+	if err := m.save(); err != nil {
+		m.statusMessage = "Could not save: " + err.Error()
+	}
+}
+
+// quit saves and exits. If the save fails the game stays open and says why,
+// so a full disk doesn't silently lose the session; quitting a second time
+// exits without saving. A buried pet is gone from the store, and saving it
+// would bring it back to be buried again.
+func (m model) quit() (tea.Model, tea.Cmd) {
+	if m.memorial != nil {
+		return m, tea.Quit
+	}
+	err := m.save()
+	if err == nil || errors.Is(err, errSpectating) || m.quitUnsaved {
+		return m, tea.Quit
+	}
+	m.quitUnsaved = true
+	m.loading = false
+	m.statusMessage = "Could not save: " + err.Error() + ". Quit again to exit without saving."
+	return m, nil
+}
+
 func (m model) Init() tea.Cmd {
-	return tea.Sequence(m.spinner.Tick, tick(), animTick())
+	return tea.Sequence(m.spinner.Tick, tick(m.sim.Rules.Tick.Duration), animTick())
 }
 
 // -- UPDATE --
 func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+    if m.game != nil && !isMainMsg(msg) {
+        return m.updateGame(msg)
+    }
     switch msg := msg.(type) {
     case tea.WindowSizeMsg:
         m.width, m.height = msg.Width, msg.Height
         m.initStars()
         return m, nil
     case tea.KeyMsg:
+        m.awayMessage = ""
+        if m.spectating() && isSpectatorBlocked(msg) {
+            m.statusMessage = "Spectating - close the other BitBuddy to look after " + m.buddy.Name + "."
+            return m, clearStatusAfter(2 * time.Second)
+        }
         // Handle rename input mode first
         if m.renaming {
             switch msg.Type {
             case tea.KeyEnter:
                 trimmed := strings.TrimSpace(m.nameInput)
+                if m.adopting {
+                    m.adopt(trimmed)
+                    return m, nil
+                }
                 if trimmed != "" {
-                    m.buddy.Name = trimmed
-                    _ = save(m.buddy)
+                    m.buddy.Rename(trimmed)
                     m.statusMessage = "Renamed to: " + trimmed
+                    m.autosave()
                 }
                 m.renaming = false
                 m.nameInput = ""
                 return m, nil
             case tea.KeyEsc:
                 m.renaming = false
+                m.adopting = false
                 m.nameInput = ""
                 return m, nil
             case tea.KeyBackspace, tea.KeyCtrlH:
@@ -277,13 +266,41 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
                 return m, nil
             }
         }
+        if m.memorial != nil {
+            switch msg.String() {
+            case "ctrl+c", "q":
+                return m, tea.Quit // Nothing left to save
+            case "enter":
+                m.renaming = true
+                m.adopting = true
+                m.nameInput = ""
+            }
+            return m, nil
+        }
         if m.loading {
             return m, nil
         }
+        if m.pickingFood {
+            return m.updateFoodPicker(msg)
+        }
+        if m.pickingGame {
+            return m.updateGamePicker(msg)
+        }
+        if m.pickingTrick {
+            return m.updateTrickPicker(msg)
+        }
+        if m.showHistory {
+            return m.updateHistory(msg)
+        }
+        if m.showAchievements {
+            return m.updateAchievements(msg)
+        }
+        if m.shopping {
+            return m.updateShop(msg)
+        }
         switch msg.String() {
         case "ctrl+c", "q":
-            _ = save(m.buddy) // Save on quit
-            return m, tea.Quit
+            return m.quit()
         case "?", "h":
             m.showHelp = !m.showHelp
             return m, nil
@@ -294,17 +311,18 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
         case "d":
             m.day = !m.day
             return m, nil
+        case "l":
+            m.showHistory = true
+            m.historyOffset = 0
+            return m, nil
+        case "a":
+            m.showAchievements = true
+            return m, nil
         case "p":
-            // Cycle pets: Cat -> Corgi -> Bunny -> Cat
-            switch m.buddy.PetType {
-            case "Cat":
-                m.buddy.PetType = "Corgi"
-            case "Corgi":
-                m.buddy.PetType = "Bunny"
-            default:
-                m.buddy.PetType = "Cat"
-            }
+            // Cycle through every registered species
+            m.buddy.SetPetType(m.sim.Species.Next(m.buddy.PetType))
             m.statusMessage = "Pet: " + m.buddy.PetType
+            m.autosave()
             return m, nil
         case "up", "k":
             if m.cursor > 0 {
@@ -321,34 +339,58 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
                 m.nameInput = m.buddy.Name
                 return m, nil
             }
-            m.loading = true
-            // Start action-specific overlays
-            m.startEffectsForAction()
-            actionCmd := func() tea.Msg {
-                time.Sleep(time.Second * 2)
-                switch m.currentAction {
-                case "Feed":
-                    m.buddy.Feed()
-                    return actionMsg{"Yum, that was tasty!"}
-                case "Play":
-                    m.buddy.Play()
-                    return actionMsg{"Weee, that was fun!"}
-                case "Sleep":
-                    m.buddy.Sleep()
-                    return actionMsg{"Zzzz..."}
+            if m.currentAction == "Shop" {
+                m.shopping = true
+                m.statusMessage = ""
+                return m, nil
+            }
+            if m.buddy.Stage == StageEgg {
+                m.currentAction = ""
+                m.statusMessage = "The egg is warm. Give it time to hatch."
+                return m, nil
+            }
+            if m.currentAction == "Sleep" {
+                return m.toggleSleep()
+            }
+            if m.buddy.Asleep {
+                m.currentAction = ""
+                m.statusMessage = "Shh... " + m.buddy.Name + " is sleeping. Wake them first."
+                return m, clearStatusAfter(2 * time.Second)
+            }
+            if m.currentAction == "Feed" {
+                m.pickingFood = true
+                return m, nil
+            }
+            if m.currentAction == "Play" {
+                if m.buddy.RefusesPlay() {
+                    m.currentAction = ""
+                    m.statusMessage = m.buddy.Name + " turns away. Maybe spend more time together first."
+                    return m, clearStatusAfter(2 * time.Second)
                 }
-                return nil
+                m.pickingGame = true
+                return m, nil
+            }
+            if m.currentAction == "Train" || m.currentAction == "Tricks" {
+                m.pickingTrick = true
+                m.trickCursor = 0
+                return m, nil
             }
-            return m, tea.Sequence(m.spinner.Tick, actionCmd)
+            return m.runAction()
 		}
 
+    case actionDueMsg:
+        return m.Update(actionMsg{m.performAction()})
+
     case actionMsg:
         m.loading = false
         m.statusMessage = msg.message
+        m.announceAchievements()
+        m.autosave()
         m.currentAction = ""
         // Clear overlays when action completes
         m.confetti = nil
         m.zzzs = nil
+        m.bubbles = nil
         clearMsgCmd := func() tea.Msg {
             time.Sleep(time.Second * 2)
             return clearStatusMsg{}
@@ -360,8 +402,47 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		return m, nil
 
 	case tickMsg:
+		if m.spectating() {
+			return m.updateSpectator()
+		}
+		wasAsleep := m.buddy.Asleep
+		calls := len(m.buddy.Calls)
 		m.buddy.UpdateStats()
-		return m, tea.Sequence(tick(), m.spinner.Tick)
+		var bell tea.Cmd
+		if m.bell && len(m.buddy.Calls) > calls {
+			bell = ringBell
+		}
+		if wasAsleep && !m.buddy.Asleep {
+			m.zzzs = nil
+			m.statusMessage = m.buddy.Name + " woke up refreshed!"
+		}
+		if m.buddy.IsDead() {
+			if m.memorial == nil {
+				m.enterMemorial()
+			}
+			return m, tick(m.sim.Rules.Tick.Duration)
+		}
+		fired := m.buddy.RollWorldEvent()
+		if fired != nil {
+			m.startEventOverlay(fired)
+			if !m.buddy.Asleep {
+				m.zzzs = nil
+			}
+			m.statusMessage = m.buddy.EventMessage(fired)
+		}
+		prev, changed := m.buddy.advanceStage()
+		if changed {
+			m.startStageTransition(prev)
+		}
+		announced := m.announceAchievements()
+		m.autosave()
+		if changed {
+			return m, tea.Batch(tea.Sequence(tick(m.sim.Rules.Tick.Duration), m.spinner.Tick), clearStatusAfter(4*time.Second), bell)
+		}
+		if announced || fired != nil || (wasAsleep && !m.buddy.Asleep) {
+			return m, tea.Batch(tea.Sequence(tick(m.sim.Rules.Tick.Duration), m.spinner.Tick), clearStatusAfter(3*time.Second), bell)
+		}
+		return m, tea.Batch(tea.Sequence(tick(m.sim.Rules.Tick.Duration), m.spinner.Tick), bell)
 
     case spinner.TickMsg:
         var cmd tea.Cmd
@@ -373,29 +454,184 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
         m.frame++
         // twinkle some stars randomly
         for i := range m.stars {
-            if rand.Intn(12) == 0 {
+            if m.sim.FX.Intn(12) == 0 {
                 m.stars[i].on = !m.stars[i].on
             }
         }
+        m.updateEventOverlay()
+        if m.evolveFrames > 0 {
+            m.evolveFrames--
+            m.updateConfetti()
+            if m.evolveFrames == 0 {
+                m.confetti = nil
+            }
+        }
         // Update overlays for current action
         if m.loading {
             switch m.currentAction {
             case "Play":
                 m.updateConfetti()
-            case "Sleep":
-                m.updateZzz()
+            case "Clean":
+                m.updateBubbles()
             }
+        } else if m.buddy.Asleep {
+            m.updateZzz()
         }
         return m, animTick()
     }
     return m, nil
 }
 
+// runAction starts m.currentAction: the spinner and overlays play while the
+// action itself runs after a short delay.
+func (m model) runAction() (tea.Model, tea.Cmd) {
+    m.loading = true
+    // Start action-specific overlays
+    m.startEffectsForAction()
+    actionCmd := func() tea.Msg {
+        time.Sleep(time.Second * 2)
+        return actionDueMsg{}
+    }
+    return m, tea.Sequence(m.spinner.Tick, actionCmd)
+}
+
+// performAction applies m.currentAction to the pet and returns what to tell
+// the player. It runs inside Update so the pet is never touched concurrently.
+func (m model) performAction() string {
+    switch m.currentAction {
+    case "Feed":
+        food, err := m.buddy.Feed(m.foodChoice)
+        if err != nil {
+            return "Can't eat: " + err.Error()
+        }
+        if food.ID == "treat" && m.buddy.OverTreated() {
+            return "Too many treats... tummy feels funny."
+        }
+        return m.buddy.Line("feed", "Yum, that "+strings.ToLower(food.Name)+" was tasty!")
+    case "Play":
+        coins := m.buddy.Play(m.gameChoice, m.gameScore)
+        line := m.buddy.Line("play", "Weee, that was fun!")
+        return fmt.Sprintf("%s\nScore %d, +%d coins", line, m.gameScore, coins)
+    case "Train":
+        trick, _ := m.sim.findTrick(m.trickChoice)
+        learned, err := m.buddy.Train(m.trickChoice)
+        if err != nil {
+            return "Can't train: " + err.Error()
+        }
+        if learned {
+            return fmt.Sprintf("Good job! %s is now level %d.", trick.Name, m.buddy.Skill(trick.ID))
+        }
+        return m.buddy.Name + " didn't get it this time."
+    case "Trick":
+        trick, _ := m.sim.findTrick(m.trickChoice)
+        if err := m.buddy.PerformTrick(m.trickChoice); err != nil {
+            return "Can't: " + err.Error()
+        }
+        return "Ta-da! " + trick.Name + "!"
+    case "Clean":
+        if m.buddy.Clean() > 0 {
+            return "Squeaky clean!"
+        }
+        return "All fresh - nothing to clean."
+    case "Medicine":
+        if err := m.buddy.Medicine(); errors.Is(err, errNotSick) {
+            return "Not sick - no medicine needed."
+        } else if err != nil {
+            return "Can't: " + err.Error()
+        }
+        return "Bleh! But feeling better already."
+    }
+    return ""
+}
+
+// toggleSleep puts the pet to bed, or wakes it if it is already asleep.
+// Unlike other actions this happens at once: sleep itself takes real time.
+func (m model) toggleSleep() (tea.Model, tea.Cmd) {
+    m.currentAction = ""
+    if m.buddy.Asleep {
+        m.zzzs = nil
+        if m.buddy.Wake() {
+            m.statusMessage = m.buddy.Name + " is grumpy about being woken up!"
+        } else {
+            m.statusMessage = "Good morning, " + m.buddy.Name + "!"
+        }
+        return m, clearStatusAfter(2 * time.Second)
+    }
+    if !m.buddy.Sleep() {
+        m.statusMessage = m.buddy.Name + " isn't tired."
+        return m, clearStatusAfter(2 * time.Second)
+    }
+    m.initZzz()
+    m.statusMessage = m.buddy.Line("sleep", "Zzzz...")
+    return m, clearStatusAfter(2 * time.Second)
+}
+
+// artAction is the action the pet's sprite should show: the running action,
+// or sleeping while the pet is in bed.
+func (m model) artAction() string {
+    if m.loading {
+        return m.currentAction
+    }
+    if m.buddy.Asleep {
+        return "Sleep"
+    }
+    return ""
+}
+
+// updateFoodPicker handles keys while choosing what to feed the pet.
+func (m model) updateFoodPicker(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+    switch msg.String() {
+    case "up", "k":
+        if m.foodCursor > 0 {
+            m.foodCursor--
+        }
+    case "down", "j":
+        if m.foodCursor < len(m.sim.Catalog.Kind(KindFood))-1 {
+            m.foodCursor++
+        }
+    case "esc", "q":
+        m.pickingFood = false
+        m.currentAction = ""
+    case "enter":
+        food := m.sim.Catalog.Kind(KindFood)[m.foodCursor]
+        if m.buddy.Inventory[food.ID] <= 0 {
+            m.statusMessage = "Out of " + food.Name + "!"
+            return m, clearStatusAfter(2 * time.Second)
+        }
+        m.pickingFood = false
+        m.foodChoice = food.ID
+        return m.runAction()
+    }
+    return m, nil
+}
+
+// renderFoodPicker lists the food catalog with what is left in the inventory.
+func (m model) renderFoodPicker() string {
+    var b strings.Builder
+    b.WriteString("What should " + m.buddy.Name + " eat?\n\n")
+    for i, food := range m.sim.Catalog.Kind(KindFood) {
+        style := menuChoiceStyle
+        cursor := " "
+        if m.foodCursor == i {
+            style = selectedChoiceStyle
+            cursor = ">"
+        }
+        line := fmt.Sprintf("%s %-10s x%-2d %s", cursor, food.Name, m.buddy.Inventory[food.ID], itemEffects(food))
+        b.WriteString(style.Render(line) + "\n")
+    }
+    if m.statusMessage != "" {
+        b.WriteString("\n" + statusMessageStyle.Render(m.statusMessage) + "\n")
+    }
+    b.WriteString(quitStyle.Render("Enter feed | Esc back"))
+    return b.String()
+}
+
 // -- VIEW --
 func (m model) View() string {
     // Animated buddy & starfield panel
     art := m.renderBuddy()
-    canvas := m.renderSky(24, 7, m.day)
+    // Lights are off while the pet sleeps
+    canvas := m.renderSky(24, 7, m.day && !m.buddy.Asleep)
     artLines := strings.Split(strings.TrimRight(art, "\n"), "\n")
     for i := range canvas {
         if i >= len(artLines) {
@@ -408,6 +644,13 @@ func (m model) View() string {
             canvas[i] = canvas[i][:inset] + line + canvas[i][inset+len(line):]
         }
     }
+    // Blinking attention icon beside the pet's head
+    if len(m.buddy.Calls) > 0 && !m.buddy.Asleep && m.memorial == nil && m.blinkOn() {
+        row := canvas[0]
+        if x := 13; x+len(attentionIcon) <= len(row) {
+            canvas[0] = row[:x] + attentionIcon + row[x+len(attentionIcon):]
+        }
+    }
     // Overlays: confetti and Zzz over the art
     for _, p := range m.confetti {
         if p.y >= 0 && p.y < len(canvas) {
@@ -419,6 +662,14 @@ func (m model) View() string {
             }
         }
     }
+    for _, p := range m.eventFX {
+        if p.y >= 0 && p.y < len(canvas) {
+            row := canvas[p.y]
+            if p.x >= 0 && p.x < len(row) {
+                canvas[p.y] = row[:p.x] + p.ch + row[p.x+1:]
+            }
+        }
+    }
     for _, z := range m.zzzs {
         if z.y >= 0 && z.y < len(canvas) {
             row := canvas[z.y]
@@ -429,9 +680,21 @@ func (m model) View() string {
             }
         }
     }
-    artPanel := lipgloss.NewStyle().
-        Padding(1, 2).
-        Render(strings.Join(canvas, "\n"))
+    for _, p := range m.bubbles {
+        if p.y >= 0 && p.y < len(canvas) {
+            row := canvas[p.y]
+            if p.x >= 0 && p.x < len(row) {
+                left := row[:p.x]
+                right := row[p.x+1:]
+                canvas[p.y] = left + p.ch + right
+            }
+        }
+    }
+    artStyle := lipgloss.NewStyle().Padding(1, 2)
+    if m.buddy.Asleep {
+        artStyle = artStyle.Foreground(lipgloss.Color("238")).Faint(true)
+    }
+    artPanel := artStyle.Render(strings.Join(canvas, "\n"))
 
     // Right side (UI)
     var ui strings.Builder
@@ -445,11 +708,34 @@ func (m model) View() string {
     }
     title += " - " + m.buddy.PetType
     ui.WriteString(titleStyle.Render(title) + "\n")
+    if m.spectating() {
+        ui.WriteString(m.spectatorLine() + "\n\n")
+    }
 
-    if m.renaming {
+    if m.renaming && m.adopting {
+        ui.WriteString("Name your new pet (Enter to hatch, Esc to go back)\n\n")
+        ui.WriteString("> " + m.nameInput + "\n\n")
+        ui.WriteString("Tip: Leave empty to call it BitBuddy")
+    } else if m.renaming {
         ui.WriteString("Rename Pet (Enter to save, Esc to cancel)\n\n")
         ui.WriteString("> " + m.nameInput + "\n\n")
-        ui.WriteString("Tip: Names are saved to bitbuddy.json")
+        ui.WriteString("Tip: Names are saved to " + m.store.String())
+    } else if m.memorial != nil {
+        ui.WriteString(m.renderMemorial())
+    } else if m.pickingFood {
+        ui.WriteString(m.renderFoodPicker())
+    } else if m.game != nil {
+        ui.WriteString(m.game.View())
+    } else if m.pickingGame {
+        ui.WriteString(m.renderGamePicker())
+    } else if m.pickingTrick {
+        ui.WriteString(m.renderTrickPicker())
+    } else if m.showHistory {
+        ui.WriteString(m.renderHistory())
+    } else if m.showAchievements {
+        ui.WriteString(m.renderAchievements())
+    } else if m.shopping {
+        ui.WriteString(m.renderShop())
     } else if m.showHelp {
         // Help overlay
         ui.WriteString("Keys:\n")
@@ -458,12 +744,29 @@ func (m model) View() string {
         ui.WriteString("  ?         Toggle help\n")
         ui.WriteString("  t         Toggle theme\n")
         ui.WriteString("  d         Toggle day/night background\n")
-        ui.WriteString("  p         Switch pet (Cat/Corgi/Bunny)\n")
+        ui.WriteString("  l         History log (Tab to filter)\n")
+        ui.WriteString("  a         Achievements\n")
+        ui.WriteString("  p         Switch pet (" + strings.Join(m.sim.Species.Names(), "/") + ")\n")
         ui.WriteString("  q         Quit\n\n")
         ui.WriteString("Legend:\n")
-        ui.WriteString("  Hunger/Happiness/Energy bars update over time.\n\n")
+        ui.WriteString("  Hunger/Happiness/Energy bars update over time.\n")
+        ui.WriteString("  Health drops with neglect; Medicine cures illness.\n")
+        ui.WriteString("  @ is a mess - Clean it up before Hygiene drops.\n")
+        ui.WriteString("  (!) means the pet is calling - answer it before it's a care mistake.\n")
+        ui.WriteString("  Play starts a minigame; better scores earn more.\n")
+        ui.WriteString("  Train tricks when rested and happy; show them off in Tricks.\n")
+        ui.WriteString("  Bond grows with daily care and fades when you stay away.\n")
+        ui.WriteString("  Personality traits change how fast stats move.\n")
+        ui.WriteString("  Good care earns coins to spend in the Shop.\n\n")
         ui.WriteString("Files:\n")
-        ui.WriteString("  bitbuddy.json - saved state (ignored by git)\n")
+        if _, ok := unwrapStore(m.store).(*FileStore); ok {
+            ui.WriteString("  " + m.store.String() + " - saved pets; the files below are kept with them\n")
+            ui.WriteString("  " + backupDir + "/ - previous saves; 'bitbuddy restore' rolls back\n")
+            ui.WriteString("  " + graveyardFile + " - pets that died (--hardcore)\n")
+        } else {
+            ui.WriteString("  " + m.store.String() + " - saved pets, and the pets that died (--hardcore)\n")
+        }
+        ui.WriteString("  *.lock - held by the BitBuddy that's playing; a second one only watches\n")
     } else {
         // Status or Bars
         if m.loading {
@@ -471,17 +774,55 @@ func (m model) View() string {
         } else if m.statusMessage != "" {
             ui.WriteString(statusMessageStyle.Render(m.statusMessage))
         } else {
+            if m.awayMessage != "" {
+                ui.WriteString(statusMessageStyle.Render(m.awayMessage) + "\n\n")
+            }
             // Mood indicator
             mood, face := computeMood(m.buddy)
-            ui.WriteString(fmt.Sprintf("Mood: %s %s\n\n", mood, face))
-            ui.WriteString(renderBar("Hunger", m.buddy.Hunger) + "\n")
-            ui.WriteString(renderBar("Happiness", m.buddy.Happiness) + "\n")
-            ui.WriteString(renderBar("Energy", m.buddy.Energy))
+            ui.WriteString(fmt.Sprintf("Mood: %s %s\n", mood, face))
+            stage := string(m.buddy.Stage)
+            if form := m.buddy.FormName(); form != "" {
+                stage += ", " + form
+            }
+            ui.WriteString(fmt.Sprintf("Stage: %s (age %s)\n", stage, formatDuration(m.buddy.Age())))
+            ui.WriteString(fmt.Sprintf("Personality: %s\n\n", m.buddy.Personality()))
+            // Bars the pet is calling about blink with a "!"
+            bar := func(label string, value int) string {
+                if m.buddy.callingFor(label) && m.blinkOn() {
+                    return renderBar("!"+label, value)
+                }
+                return renderBar(label, value)
+            }
+            ui.WriteString(bar("Hunger", m.buddy.Hunger) + "\n")
+            ui.WriteString(bar("Happiness", m.buddy.Happiness) + "\n")
+            ui.WriteString(bar("Energy", m.buddy.Energy) + "\n")
+            ui.WriteString(bar("Health", m.buddy.Health) + "\n")
+            ui.WriteString(bar("Hygiene", m.buddy.Hygiene) + "\n")
+            ui.WriteString(renderBar("Bond", m.buddy.Bond()) + "\n")
+            weight := fmt.Sprintf("Weight     %d", m.buddy.Weight)
+            if m.buddy.IsOverweight() {
+                weight = sickStyle.Render(weight + " (overweight)")
+            }
+            ui.WriteString(weight)
+            ui.WriteString(fmt.Sprintf("\nCoins      %d", m.buddy.Coins))
+            if m.buddy.IsSick() {
+                ui.WriteString("\n" + sickStyle.Render(fmt.Sprintf("Sick: %s - needs Medicine", m.buddy.Illness)))
+            }
+            if m.buddy.Asleep {
+                ui.WriteString(fmt.Sprintf("\nAsleep for %s", formatDuration(m.buddy.SleptFor())))
+            }
+            ui.WriteString("\n" + menuChoiceStyle.Render(m.lastCareLine()))
+            if call := m.callLine(); call != "" {
+                ui.WriteString("\n" + sickStyle.Render(call))
+            }
         }
         ui.WriteString("\n\n")
 
         // Menu
         for i, choice := range m.choices {
+            if choice == "Sleep" && m.buddy.Asleep {
+                choice = "Wake"
+            }
             style := menuChoiceStyle
             cursor := " "
             if m.cursor == i {
@@ -490,7 +831,7 @@ func (m model) View() string {
             }
             ui.WriteString(style.Render(fmt.Sprintf("%s %s", cursor, choice)) + "\n")
         }
-        ui.WriteString(quitStyle.Render("Press '?' for help | 't' theme | 'd' day/night | 'q' quit"))
+        ui.WriteString(quitStyle.Render("Press '?' for help | 'l' history | 't' theme | 'q' quit"))
     }
     uiPanel := uiPanelStyle.Render(ui.String())
 
@@ -526,28 +867,36 @@ func computeMood(b *BitBuddy) (string, string) {
     if b == nil {
         return "--", "--"
     }
+    t := b.rules().Mood
     score := b.Happiness + b.Energy - b.Hunger
     switch {
-    case score >= 120:
+    case score >= t.Ecstatic:
         return "Ecstatic", ":D"
-    case score >= 60:
+    case score >= t.Happy:
         return "Happy", ":)"
-    case score >= 20:
+    case score >= t.Okay:
         return "Okay", ":|"
-    case score >= -20:
+    case score >= t.Tired:
         return "Tired", "-_-"
     default:
         return "Grumpy", ":("
     }
 }
 
-// tick is a command that sends a tickMsg every 5 seconds.
-func tick() tea.Cmd {
-    return tea.Tick(time.Second*5, func(t time.Time) tea.Msg {
+// tick is a command that sends a tickMsg every d (the rules' tick).
+func tick(d time.Duration) tea.Cmd {
+    return tea.Tick(d, func(t time.Time) tea.Msg {
         return tickMsg{}
     })
 }
 
+// clearStatusAfter clears the status message once d has passed.
+func clearStatusAfter(d time.Duration) tea.Cmd {
+    return tea.Tick(d, func(t time.Time) tea.Msg {
+        return clearStatusMsg{}
+    })
+}
+
 // animTick is a faster tick for UI animations
 func animTick() tea.Cmd {
     return tea.Tick(time.Millisecond*120, func(t time.Time) tea.Msg {
@@ -561,9 +910,9 @@ func (m *model) initStars() {
     if len(m.stars) == 0 {
         for i := 0; i < 30; i++ {
             m.stars = append(m.stars, star{
-                x:  rand.Intn(width),
-                y:  rand.Intn(height),
-                on: rand.Intn(2) == 0,
+                x:  m.sim.FX.Intn(width),
+                y:  m.sim.FX.Intn(height),
+                on: m.sim.FX.Intn(2) == 0,
             })
         }
     }
@@ -586,9 +935,28 @@ func (m model) renderSky(width, height int, day bool) []string {
             right := rows[y][x+len(sun):]
             rows[y] = left + sun + right
         }
+        return m.drawMesses(rows)
+    }
+    return m.drawMesses(m.renderStars(width, height))
+}
+
+// drawMesses places the pet's uncleaned messes on the ground row, with
+// stink lines above them that waft every few frames.
+func (m model) drawMesses(rows []string) []string {
+    if m.buddy == nil || len(rows) < 2 {
         return rows
     }
-    return m.renderStars(width, height)
+    ground := len(rows) - 1
+    for _, mess := range m.buddy.Messes {
+        if mess.X < 0 || mess.X >= len(rows[ground]) {
+            continue
+        }
+        rows[ground] = rows[ground][:mess.X] + "@" + rows[ground][mess.X+1:]
+        if m.frame%4 < 2 {
+            rows[ground-1] = rows[ground-1][:mess.X] + "~" + rows[ground-1][mess.X+1:]
+        }
+    }
+    return rows
 }
 
 func (m model) renderStars(width, height int) []string {
@@ -609,59 +977,60 @@ func (m model) renderStars(width, height int) []string {
     return rows
 }
 
+// renderBuddy returns the sprite for the pet's life stage, flickering between
+// the old and new stage while a stage transition plays.
 func (m model) renderBuddy() string {
-    isDog := m.buddy != nil && strings.EqualFold(m.buddy.PetType, "Corgi")
-    isBun := m.buddy != nil && strings.EqualFold(m.buddy.PetType, "Bunny")
-    if m.loading {
-        switch m.currentAction {
-        case "Feed":
-            if isDog {
-                if m.frame%2 == 0 { return dogEat1 }
-                return dogEat2
-            } else if isBun {
-                if m.frame%2 == 0 { return bunEat1 }
-                return bunEat2
-            }
-            if m.frame%2 == 0 { return catEat1 }
-            return catEat2
-        case "Play":
-            if isDog {
-                if m.frame%2 == 0 { return dogPlay1 }
-                return dogPlay2
-            } else if isBun {
-                if m.frame%2 == 0 { return bunPlay1 }
-                return bunPlay2
-            }
-            if m.frame%2 == 0 { return catPlay1 }
-            return catPlay2
-        case "Sleep":
-            if isDog {
-                if m.frame%2 == 0 { return dogSleep1 }
-                return dogSleep2
-            } else if isBun {
-                if m.frame%2 == 0 { return bunSleep1 }
-                return bunSleep2
-            }
-            if m.frame%2 == 0 { return catSleep1 }
-            return catSleep2
-        default:
-            // fall through to idle
-        }
-    }
-    switch m.frame % 3 {
-    case 0:
-        if isDog { return dogIdle1 }
-        if isBun { return bunIdle1 }
-        return catIdle1
-    case 1:
-        if isDog { return dogIdle2 }
-        if isBun { return bunIdle2 }
-        return catIdle2
-    default:
-        if isDog { return dogIdle3 }
-        if isBun { return bunIdle3 }
-        return catIdle3
+    if m.memorial != nil {
+        return tombstoneArt
+    }
+    if m.evolveFrames > 0 && (m.frame/2)%2 == 0 {
+        // Flicker back to the pre-evolution art
+        return m.stageArt(m.evolveFrom, m.speciesArt(""))
+    }
+    art := m.stageArt(m.buddy.Stage, m.speciesArt(m.buddy.Form))
+    // Trick animations replace the whole sprite, legs and all
+    if trick, ok := m.sim.findTrick(m.trickChoice); ok && m.artAction() == "Trick" {
+        art = trick.Frame(m.frame / 3)
+    }
+    if m.buddy.IsSick() {
+        art = sickArt(art, m.frame)
+    }
+    if item, ok := m.sim.Catalog.Get(m.buddy.Wearing); ok && m.buddy.Stage != StageEgg {
+        art = item.Art + "\n" + art
+    }
+    return art
+}
+
+// sickFaces swaps the usual eyes for dizzy ones while the pet is ill.
+var sickFaces = strings.NewReplacer(
+    "o.o", "x.x", "o_o", "x_x", "-_-", "x_x", "^.^", "x.x", "^o^", "x.x",
+)
+
+// sickArt turns any sprite into its sick version: dizzy eyes and a
+// thermometer that bobs next to the head.
+func sickArt(art string, frame int) string {
+    lines := strings.Split(strings.TrimRight(sickFaces.Replace(art), "\n"), "\n")
+    row := 0
+    if frame%4 >= 2 && len(lines) > 1 {
+        row = 1
+    }
+    lines[row] = strings.TrimRight(lines[row], " ") + " ~i"
+    return strings.Join(lines, "\n") + "\n"
+}
+
+// speciesArt picks the species sprite for the current action and frame.
+func (m model) speciesArt(form string) string {
+    state := m.buddy.idleState(m.frame)
+    switch m.artAction() {
+    case "Feed":
+        state = "eat"
+    case "Play", "Train":
+        state = "play"
+
+    case "Sleep":
+        state = "sleep"
     }
+    return m.sim.Species.Get(m.buddy.PetType).FormFrame(form, state, m.frame)
 }
 
 // -- THEME --
@@ -693,16 +1062,100 @@ func setTheme(dark bool) {
     }
 }
 
-// -- OVERLAYS: CONFETTI & ZZZ --
+// -- STAGE TRANSITION --
+// startStageTransition flashes between the old and new stage sprites with a
+// shower of sparkles, and announces the new stage.
+func (m *model) startStageTransition(from LifeStage) {
+    m.evolveFrom = from
+    m.evolveFrames = 24
+    m.initConfetti()
+    if from == StageEgg {
+        m.statusMessage = m.buddy.Name + " hatched!"
+    } else {
+        m.statusMessage = fmt.Sprintf("%s grew up: %s -> %s", m.buddy.Name, from, m.buddy.Stage)
+    }
+    if form := m.buddy.FormName(); form != "" && m.buddy.Stage == StageAdult {
+        m.statusMessage += "\n" + m.buddy.Name + " evolved into a " + form + "!"
+    }
+}
+
+// -- DEATH & ADOPTION --
+const tombstoneArt = "" +
+    "   .---.    \n" +
+    "  / RIP \\   \n" +
+    "  |     |   \n" +
+    "  |     |   \n" +
+    " ~~~~~~~~~  \n"
+
+// enterMemorial buries the dead pet and switches to the memorial screen. A
+// spectator only shows the memorial; burying is up to the instance that owns
+// the save.
+func (m *model) enterMemorial() {
+    var err error
+    memorial := memorialFor(m.buddy)
+    if !m.spectating() {
+        memorial, err = bury(m.store, m.key, m.buddy)
+    }
+    m.memorial = &memorial
+    m.game = nil
+    m.loading = false
+    m.confetti = nil
+    m.zzzs = nil
+    m.evolveFrames = 0
+    if err != nil {
+        m.statusMessage = "Could not archive to graveyard: " + err.Error()
+    }
+}
+
+// renderMemorial shows who the pet was, how long it lived and what happened.
+func (m model) renderMemorial() string {
+    var b strings.Builder
+    mem := m.memorial
+    b.WriteString(fmt.Sprintf("In memory of %s\n\n", mem.Name))
+    b.WriteString(fmt.Sprintf("  %-10s %s\n", "Type", mem.PetType))
+    b.WriteString(fmt.Sprintf("  %-10s %s\n", "Stage", mem.Stage))
+    b.WriteString(fmt.Sprintf("  %-10s %s\n", "Born", mem.BornAt.Format("2006-01-02 15:04")))
+    b.WriteString(fmt.Sprintf("  %-10s %s\n", "Lifespan", formatDuration(mem.Lifespan())))
+    b.WriteString(fmt.Sprintf("  %-10s %s\n", "Cause", mem.Cause))
+    b.WriteString(fmt.Sprintf("  %-10s %d\n\n", "Mistakes", mem.CareMistakes))
+    if m.statusMessage != "" {
+        b.WriteString(sickStyle.Render(m.statusMessage) + "\n\n")
+    }
+    b.WriteString("Resting in the graveyard in " + m.store.String() + "\n")
+    b.WriteString(quitStyle.Render("Enter adopt a new pet | 'q' quit"))
+    return b.String()
+}
+
+// adopt starts over with a new egg, keeping the player's pet type and
+// hardcore choice.
+func (m *model) adopt(name string) {
+    if name == "" {
+        name = "BitBuddy"
+    }
+    old := m.buddy
+    m.buddy = NewBitBuddy(m.sim, name)
+    m.buddy.PetType = old.PetType
+    m.buddy.Hardcore = old.Hardcore
+    m.memorial = nil
+    m.renaming = false
+    m.adopting = false
+    m.nameInput = ""
+    m.cursor = 0
+    m.statusMessage = "Welcome, " + name + "!"
+    m.autosave()
+}
+
+// -- OVERLAYS: CONFETTI, ZZZ & BUBBLES --
 func (m *model) startEffectsForAction() {
     switch m.currentAction {
     case "Play":
         m.initConfetti()
-    case "Sleep":
-        m.initZzz()
+    case "Clean":
+        m.initBubbles()
     default:
         m.confetti = nil
         m.zzzs = nil
+        m.bubbles = nil
     }
 }
 
@@ -711,13 +1164,13 @@ func (m *model) initConfetti() {
     w := 24
     glyphs := []string{"*", "+", "x"}
     for i := 0; i < 22; i++ {
-        g := glyphs[rand.Intn(len(glyphs))]
+        g := glyphs[m.sim.FX.Intn(len(glyphs))]
         p := confettiParticle{
-            x:   rand.Intn(w),
-            y:   rand.Intn(2), // top rows
-            dx:  rand.Intn(3) - 1,
+            x:   m.sim.FX.Intn(w),
+            y:   m.sim.FX.Intn(2), // top rows
+            dx:  m.sim.FX.Intn(3) - 1,
             dy:  1,
-            life: 8 + rand.Intn(7),
+            life: 8 + m.sim.FX.Intn(7),
             ch:  g,
         }
         m.confetti = append(m.confetti, p)
@@ -736,8 +1189,8 @@ func (m *model) updateConfetti() {
             continue
         }
         // small horizontal jitter
-        if rand.Intn(3) == 0 {
-            p.x += (rand.Intn(3) - 1)
+        if m.sim.FX.Intn(3) == 0 {
+            p.x += (m.sim.FX.Intn(3) - 1)
             if p.x < 0 {
                 p.x = 0
             }
@@ -752,12 +1205,12 @@ func (m *model) updateConfetti() {
     if len(m.confetti) < 18 {
         g := []string{"*", "+", "x"}
         m.confetti = append(m.confetti, confettiParticle{
-            x:   rand.Intn(24),
+            x:   m.sim.FX.Intn(24),
             y:   0,
-            dx:  rand.Intn(3) - 1,
+            dx:  m.sim.FX.Intn(3) - 1,
             dy:  1,
             life: 10,
-            ch:  g[rand.Intn(len(g))],
+            ch:  g[m.sim.FX.Intn(len(g))],
         })
     }
 }
@@ -776,10 +1229,10 @@ func (m *model) updateZzz() {
     for i := range m.zzzs {
         z := m.zzzs[i]
         // drift up-right slowly
-        if rand.Intn(2) == 0 {
+        if m.sim.FX.Intn(2) == 0 {
             z.x += 1
         }
-        if rand.Intn(2) == 0 {
+        if m.sim.FX.Intn(2) == 0 {
             z.y -= 1
         }
         if z.x >= w {
@@ -794,9 +1247,55 @@ func (m *model) updateZzz() {
     m.zzzs = next
     // spawn new z every few frames, up to a small count
     if len(m.zzzs) < 4 && m.frame%3 == 0 {
-        startX := 12 + rand.Intn(3) - 1
+        startX := 12 + m.sim.FX.Intn(3) - 1
         startY := 2
         texts := []string{"z", "zz", "zzz"}
-        m.zzzs = append(m.zzzs, zzzParticle{x: startX, y: startY, life: 14, text: texts[rand.Intn(len(texts))]})
+        m.zzzs = append(m.zzzs, zzzParticle{x: startX, y: startY, life: 14, text: texts[m.sim.FX.Intn(len(texts))]})
+    }
+}
+
+func (m *model) initBubbles() {
+    m.bubbles = nil
+    // start along the ground, where the messes are
+    w, h := 24, 7
+    for i := 0; i < 10; i++ {
+        m.bubbles = append(m.bubbles, bubbleParticle{
+            x:    m.sim.FX.Intn(w),
+            y:    h - 1 - m.sim.FX.Intn(2),
+            life: 6 + m.sim.FX.Intn(6),
+            ch:   "o",
+        })
+    }
+}
+
+func (m *model) updateBubbles() {
+    w, h := 24, 7
+    next := m.bubbles[:0]
+    for i := range m.bubbles {
+        p := m.bubbles[i]
+        // float up, wobbling sideways, and grow before popping
+        p.y--
+        p.x += m.sim.FX.Intn(3) - 1
+        p.life--
+        switch {
+        case p.life <= 2:
+            p.ch = "."
+        case p.life <= 5:
+            p.ch = "O"
+        }
+        if p.x < 0 || p.x >= w || p.y < 0 || p.life <= 0 {
+            continue
+        }
+        next = append(next, p)
+    }
+    m.bubbles = next
+    // keep the suds coming while cleaning
+    if len(m.bubbles) < 12 {
+        m.bubbles = append(m.bubbles, bubbleParticle{
+            x:    m.sim.FX.Intn(w),
+            y:    h - 1,
+            life: 8,
+            ch:   "o",
+        })
     }
 }
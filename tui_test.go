@@ -0,0 +1,88 @@
+package main
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// fullDiskStore fails every save, like a store on a full disk.
+type fullDiskStore struct{ Store }
+
+func (fullDiskStore) Save(string, *BitBuddy) error { return errors.New("no space left on device") }
+
+// A failed save on quit keeps the game open and says why; quitting again
+// exits without saving.
+func TestQuitWhenSaveFails(t *testing.T) {
+	sim := NewSim(nil, 1)
+	m := initialModel(sim, fullDiskStore{NewMemoryStore()}, defaultPetKey, NewBitBuddy(sim, "Stuck"))
+	q := tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("q")}
+
+	next, cmd := m.Update(q)
+	m = next.(model)
+	if cmd != nil {
+		t.Fatal("quit even though the save failed")
+	}
+	if m.statusMessage == "" {
+		t.Fatal("save error not shown")
+	}
+	if _, cmd := m.Update(q); cmd == nil {
+		t.Fatal("second quit didn't exit")
+	}
+}
+
+// A pet that dies mid-game is buried once: the game ends with it, and
+// quitting doesn't save it back into the store.
+func TestQuitAfterDeathInGame(t *testing.T) {
+	sim := NewSim(nil, 1)
+	store := NewMemoryStore()
+	buddy := NewBitBuddy(sim, "Player")
+	m := initialModel(sim, store, defaultPetKey, buddy)
+	m.game = newGuessGame(sim)
+	buddy.DiedAt, buddy.CauseOfDeath = sim.Now(), "Neglect"
+
+	next, _ := m.Update(tickMsg{})
+	m = next.(model)
+	if m.memorial == nil || m.game != nil {
+		t.Fatalf("memorial = %v, game = %v after dying mid-game", m.memorial, m.game)
+	}
+	if _, cmd := m.quit(); cmd == nil {
+		t.Fatal("quit didn't exit")
+	}
+	if _, err := store.Load(defaultPetKey); !errors.Is(err, errNoPet) {
+		t.Fatalf("quitting saved the buried pet: %v", err)
+	}
+	if graves, _ := store.Graveyard(); len(graves) != 1 {
+		t.Fatalf("buried %d times", len(graves))
+	}
+}
+
+// Every change the player makes outside the usual actions is saved, and a
+// failed save says so.
+func TestSaveErrorsShown(t *testing.T) {
+	enter := tea.KeyMsg{Type: tea.KeyEnter}
+	key := func(s string) tea.KeyMsg { return tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(s)} }
+	tests := []struct {
+		name  string
+		setup func(m *model)
+		msg   tea.KeyMsg
+	}{
+		{"rename", func(m *model) { m.renaming, m.nameInput = true, "Newname" }, enter},
+		{"adopt", func(m *model) { m.renaming, m.adopting, m.nameInput = true, true, "Egg" }, enter},
+		{"switch species", func(m *model) {}, key("p")},
+		{"buy", func(m *model) { m.shopping, m.buddy.Coins = true, 1000 }, enter},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sim := NewSim(nil, 1)
+			m := initialModel(sim, fullDiskStore{NewMemoryStore()}, defaultPetKey, NewBitBuddy(sim, "Stuck"))
+			tt.setup(&m)
+			next, _ := m.Update(tt.msg)
+			if got := next.(model).statusMessage; !strings.HasPrefix(got, "Could not save") {
+				t.Errorf("status = %q, want the save error", got)
+			}
+		})
+	}
+}
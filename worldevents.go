@@ -0,0 +1,276 @@
+package main
+
+import (
+	"embed"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// defaultWorldEvents holds the random events that can happen on a tick.
+//
+//go:embed events/events.json
+var defaultWorldEvents embed.FS
+
+// WorldEvent is something that happens to the pet on its own: on every tick
+// there is an events.chance percent chance that one eligible event, picked
+// by weight, fires.
+type WorldEvent struct {
+	ID       string         `json:"id"`
+	Name     string         `json:"name"`
+	Message  string         `json:"message"` // toast; {name} is the pet's name
+	Weight   int            `json:"weight"`
+	Cooldown Duration       `json:"cooldown"` // minimum time between two of these
+	When     EventCondition `json:"when"`
+	Effects  EventEffects   `json:"effects"`
+	Overlay  EventOverlay   `json:"overlay"`
+}
+
+// EventCondition limits when an event can fire. Unset fields don't
+// constrain anything.
+type EventCondition struct {
+	Awake    bool           `json:"awake,omitempty"`   // not while asleep
+	Healthy  bool           `json:"healthy,omitempty"` // not while sick
+	MinStage LifeStage      `json:"min_stage,omitempty"`
+	Below    map[string]int `json:"below,omitempty"` // stat -> must be under this
+	Above    map[string]int `json:"above,omitempty"` // stat -> must be over this
+}
+
+// EventEffects are applied when an event fires.
+type EventEffects struct {
+	Hunger    int     `json:"hunger,omitempty"`
+	Happiness int     `json:"happiness,omitempty"`
+	Energy    int     `json:"energy,omitempty"`
+	Health    int     `json:"health,omitempty"`
+	Hygiene   int     `json:"hygiene,omitempty"`
+	Coins     int     `json:"coins,omitempty"`
+	Illness   Illness `json:"illness,omitempty"`
+	Wake      bool    `json:"wake,omitempty"` // wakes a sleeping pet
+}
+
+// EventOverlay is the animation drawn over the canvas while an event plays.
+// Motion is "fall" (from the sky), "rise" (up from the pet) or "fly"
+// (across the sky).
+type EventOverlay struct {
+	Glyphs []string `json:"glyphs"`
+	Count  int      `json:"count"`
+	Motion string   `json:"motion"`
+	Frames int      `json:"frames"` // how many animation frames it lasts
+}
+
+var overlayMotions = []string{"fall", "rise", "fly"}
+
+// loadWorldEvents parses the embedded events.
+func loadWorldEvents() []WorldEvent {
+	return mustParse(defaultWorldEvents, "events/events.json", parseWorldEvents)
+}
+
+// parseWorldEvents decodes and validates a list of world events.
+func parseWorldEvents(data []byte) ([]WorldEvent, error) {
+	var events []WorldEvent
+	if err := json.Unmarshal(data, &events); err != nil {
+		return nil, err
+	}
+	var errs []error
+	for _, e := range events {
+		if err := e.validate(); err != nil {
+			errs = append(errs, fmt.Errorf("event %q: %w", e.ID, err))
+		}
+	}
+	return events, errors.Join(errs...)
+}
+
+func (e WorldEvent) validate() error {
+	switch {
+	case e.ID == "" || e.Message == "":
+		return errors.New("id and message are required")
+	case e.Weight <= 0:
+		return errors.New("weight must be positive")
+	case e.When.MinStage != "" && stageIndex(e.When.MinStage) < 0:
+		return fmt.Errorf("unknown stage %q", e.When.MinStage)
+	case e.Effects.Illness != IllnessNone && !containsIllness(e.Effects.Illness):
+		return fmt.Errorf("unknown illness %q", e.Effects.Illness)
+	case e.Overlay.Count > 0 && (len(e.Overlay.Glyphs) == 0 || !contains(overlayMotions, e.Overlay.Motion)):
+		return errors.New("overlay needs glyphs and a motion of fall, rise or fly")
+	}
+	for stat := range e.When.Below {
+		if !contains(statOrder, stat) {
+			return fmt.Errorf("unknown stat %q", stat)
+		}
+	}
+	for stat := range e.When.Above {
+		if !contains(statOrder, stat) {
+			return fmt.Errorf("unknown stat %q", stat)
+		}
+	}
+	return nil
+}
+
+func containsIllness(i Illness) bool {
+	for _, known := range []Illness{IllnessCold, IllnessStomachAche, IllnessExhaustion} {
+		if i == known {
+			return true
+		}
+	}
+	return false
+}
+
+// eligible reports whether e may fire for b right now.
+func (e WorldEvent) eligible(b *BitBuddy) bool {
+	c := e.When
+	if last, ok := b.EventsFired[e.ID]; ok && b.now().Sub(last) < e.Cooldown.Duration {
+		return false
+	}
+	if (c.Awake && b.Asleep) || (c.Healthy && b.IsSick()) {
+		return false
+	}
+	if c.MinStage != "" && stageIndex(b.Stage) < stageIndex(c.MinStage) {
+		return false
+	}
+	stats := b.snapshot()
+	for stat, v := range c.Below {
+		if stats[stat] >= v {
+			return false
+		}
+	}
+	for stat, v := range c.Above {
+		if stats[stat] <= v {
+			return false
+		}
+	}
+	return true
+}
+
+// RollWorldEvent runs once per live tick. It may fire one random event,
+// apply its effects and record it; it returns the event, or nil if nothing
+// happened. Rolls come from the Sim's own event stream, so a seeded run
+// sees the same events whatever the animations do.
+func (b *BitBuddy) RollWorldEvent() *WorldEvent {
+	if b.IsDead() || b.sim.Events.Intn(100) >= b.rules().Events.Chance {
+		return nil
+	}
+	var eligible []*WorldEvent
+	total := 0
+	for i := range b.sim.WorldEvents {
+		if e := &b.sim.WorldEvents[i]; e.eligible(b) {
+			eligible = append(eligible, e)
+			total += e.Weight
+		}
+	}
+	if total == 0 {
+		return nil
+	}
+	pick := b.sim.Events.Intn(total)
+	for _, e := range eligible {
+		if pick < e.Weight {
+			b.fireWorldEvent(e)
+			return e
+		}
+		pick -= e.Weight
+	}
+	return nil
+}
+
+// fireWorldEvent applies an event's effects and records it.
+func (b *BitBuddy) fireWorldEvent(e *WorldEvent) {
+	before := b.snapshot()
+	fx := e.Effects
+	b.Hunger = clampStat(b.Hunger + fx.Hunger)
+	b.Happiness = clampStat(b.Happiness + fx.Happiness)
+	b.Energy = clampStat(b.Energy + fx.Energy)
+	b.Health = clampStat(b.Health + fx.Health)
+	b.Hygiene = clampStat(b.Hygiene + fx.Hygiene)
+	if fx.Coins != 0 {
+		b.transact(fx.Coins, "event: "+strings.ToLower(e.Name))
+	}
+	if fx.Wake && b.Asleep {
+		b.wakeUp()
+	}
+	if b.EventsFired == nil {
+		b.EventsFired = make(map[string]time.Time)
+	}
+	b.EventsFired[e.ID] = b.now()
+	b.History = append(b.History, Event{
+		At:      b.now(),
+		Kind:    EventWorld,
+		Action:  e.ID,
+		Detail:  e.Name,
+		Changes: before.changes(b),
+	})
+	if fx.Illness != IllnessNone && !b.IsSick() {
+		b.fallIll(fx.Illness)
+	}
+}
+
+// EventMessage is the toast for an event.
+func (b *BitBuddy) EventMessage(e *WorldEvent) string {
+	return strings.ReplaceAll(e.Message, "{name}", b.Name)
+}
+
+// -- EVENT OVERLAYS --
+
+// eventParticle is one glyph of a world event overlay.
+type eventParticle struct {
+	x, y int
+	ch   string
+}
+
+// startEventOverlay sets up the canvas animation for an event.
+func (m *model) startEventOverlay(e *WorldEvent) {
+	o := e.Overlay
+	m.eventFX = nil
+	m.eventOverlay = o
+	m.eventFrames = o.Frames
+	for i := 0; i < o.Count; i++ {
+		p := eventParticle{ch: o.Glyphs[m.sim.FX.Intn(len(o.Glyphs))]}
+		switch o.Motion {
+		case "fall":
+			p.x, p.y = m.sim.FX.Intn(24), m.sim.FX.Intn(7)
+		case "rise":
+			p.x, p.y = 2+m.sim.FX.Intn(10), 4+m.sim.FX.Intn(3)
+		case "fly":
+			p.x, p.y = -i*4, i%2
+		}
+		m.eventFX = append(m.eventFX, p)
+	}
+}
+
+// updateEventOverlay moves the overlay on one animation frame.
+func (m *model) updateEventOverlay() {
+	if m.eventFrames == 0 {
+		return
+	}
+	m.eventFrames--
+	if m.eventFrames == 0 {
+		m.eventFX = nil
+		return
+	}
+	w, h := 24, 7
+	for i := range m.eventFX {
+		p := &m.eventFX[i]
+		switch m.eventOverlay.Motion {
+		case "fall":
+			p.y++
+			if p.y >= h {
+				p.x, p.y = m.sim.FX.Intn(w), 0
+			}
+		case "rise":
+			if m.eventFrames%3 == 0 {
+				p.y--
+			}
+			if p.y < 0 {
+				p.x, p.y = 2+m.sim.FX.Intn(10), h-1
+			}
+		case "fly":
+			p.x++
+			if m.eventFrames%4 == 0 {
+				p.y = (p.y + 1) % 2
+			}
+			if p.x >= w {
+				p.x = -2
+			}
+		}
+	}
+}